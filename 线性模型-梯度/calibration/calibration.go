@@ -0,0 +1,285 @@
+// Package calibration 校准二分类模型输出的概率：Platt 缩放和保序回归（isotonic），
+// 接口仿照 scikit-learn 的 sklearn.calibration。
+//
+// 校准器只看模型的得分，因此可以包装任意训练好的分类器：logreg 中手写的 (b, w) 模型
+// 用 Logistic 得到得分，pa-m/sklearn 的 LogisticRegression 等输出概率的模型用 FromProbas。
+// 校准器必须在没有参与模型训练的样本上拟合，否则训练集上过于自信的得分会被当成准确的，
+// 可以留出一部分样本，或者用 CrossCalibrate 配合交叉验证得到的折外得分。
+package calibration
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"ai/cv"
+	"ai/logreg"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Calibrator 把分类器的得分映射成校准后的正类概率
+type Calibrator interface {
+	// Fit 用得分 scores 和 0/1 标签 yTrue 拟合映射
+	Fit(scores []float64, yTrue []int) error
+	// Transform 返回每个得分对应的校准概率
+	Transform(scores []float64) []float64
+	// Name 返回 New 中使用的名称
+	Name() string
+}
+
+// Names 返回全部校准方法
+func Names() []string {
+	return []string{"platt", "isotonic"}
+}
+
+// New 按名称创建未拟合的校准器
+func New(name string) (Calibrator, error) {
+	switch name {
+	case "platt":
+		return &Platt{}, nil
+	case "isotonic":
+		return &Isotonic{}, nil
+	}
+	return nil, fmt.Errorf("calibration: 未知的校准方法 %q，可选：%v", name, Names())
+}
+
+func checkFit(scores []float64, yTrue []int) error {
+	if len(scores) != len(yTrue) {
+		return fmt.Errorf("calibration: 得分有 %d 个，标签有 %d 个", len(scores), len(yTrue))
+	}
+	if len(scores) == 0 {
+		return fmt.Errorf("calibration: 没有用于拟合校准器的样本")
+	}
+	return nil
+}
+
+// Platt Platt 缩放：p = σ(A·s + B)，即以得分 s 为唯一特征的一元逻辑回归。
+// 得分本身是 log-odds 且只差一个仿射变换时效果最好，拟合后的函数总是 S 形的
+type Platt struct {
+	A, B float64
+}
+
+func (p *Platt) Name() string { return "platt" }
+
+// Fit 按 Platt (1999) 和 Lin 等 (2007) 的做法，把标签平滑成 (N₊+1)/(N₊+2) 和 1/(N₋+2)
+// 以免样本线性可分时 A 发散，再用带回溯线搜索的牛顿法最小化交叉熵
+func (p *Platt) Fit(scores []float64, yTrue []int) error {
+	if err := checkFit(scores, yTrue); err != nil {
+		return err
+	}
+	nPos := 0
+	for _, y := range yTrue {
+		nPos += y
+	}
+	nNeg := len(yTrue) - nPos
+	hi, lo := float64(nPos+1)/float64(nPos+2), 1/float64(nNeg+2)
+	target := make([]float64, len(yTrue))
+	for i, y := range yTrue {
+		target[i] = lo
+		if y == 1 {
+			target[i] = hi
+		}
+	}
+	loss := func(a, b float64) float64 {
+		sum := 0.0
+		for i, s := range scores {
+			g := a*s + b
+			sum -= target[i]*logreg.LogSigmoid(g) + (1-target[i])*logreg.LogSigmoid(-g)
+		}
+		return sum
+	}
+
+	const (
+		maxIter = 100
+		ridge   = 1e-12 // Hessian 对角线上的小量，保证可逆
+		tol     = 1e-10
+	)
+	a, b := 0.0, math.Log(float64(nPos+1)/float64(nNeg+1))
+	f := loss(a, b)
+	for iter := 0; iter < maxIter; iter++ {
+		var gA, gB, hAA, hAB, hBB float64
+		for i, s := range scores {
+			q := logreg.Sigmoid(a*s + b)
+			d, w := q-target[i], q*(1-q)
+			gA += d * s
+			gB += d
+			hAA += w * s * s
+			hAB += w * s
+			hBB += w
+		}
+		if math.Abs(gA) < tol && math.Abs(gB) < tol {
+			break
+		}
+		hAA += ridge
+		hBB += ridge
+		det := hAA*hBB - hAB*hAB
+		dA, dB := (hBB*gA-hAB*gB)/det, (hAA*gB-hAB*gA)/det
+
+		// 回溯线搜索，步长过小时说明已经到达浮点精度的极限
+		t, improved := 1.0, false
+		for ; t >= 1e-10; t /= 2 {
+			na, nb := a-t*dA, b-t*dB
+			if nf := loss(na, nb); nf < f+1e-4*t*(gA*-dA+gB*-dB) {
+				a, b, f, improved = na, nb, nf, true
+				break
+			}
+		}
+		if !improved {
+			break
+		}
+	}
+	p.A, p.B = a, b
+	return nil
+}
+
+func (p *Platt) Transform(scores []float64) []float64 {
+	prob := make([]float64, len(scores))
+	for i, s := range scores {
+		prob[i] = logreg.Sigmoid(p.A*s + p.B)
+	}
+	return prob
+}
+
+// Isotonic 保序回归：在所有单调不减的函数中找与 0/1 标签平方误差最小的一个，
+// 不假设校准曲线的形状，但样本少时容易过拟合成阶梯。
+// 拟合结果是以 (X[k], Y[k]) 为节点的分段线性函数，超出节点范围的得分取端点的值
+type Isotonic struct {
+	X, Y []float64
+}
+
+func (c *Isotonic) Name() string { return "isotonic" }
+
+// Fit 用 pool adjacent violators 算法：按得分从小到大扫描，相邻两段的均值违反单调性时合并成一段；
+// 得分相同的样本先合并成一个点
+func (c *Isotonic) Fit(scores []float64, yTrue []int) error {
+	if err := checkFit(scores, yTrue); err != nil {
+		return err
+	}
+	order := make([]int, len(scores))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool { return scores[order[a]] < scores[order[b]] })
+
+	// block 得分在 [lo, hi] 内的一段样本，拟合值是这些样本标签的均值
+	type block struct {
+		lo, hi, sum float64
+		n           int
+	}
+	mean := func(b block) float64 { return b.sum / float64(b.n) }
+	var blocks []block
+	for _, i := range order {
+		s, y := scores[i], float64(yTrue[i])
+		if last := len(blocks) - 1; last >= 0 && blocks[last].hi == s {
+			blocks[last].sum += y
+			blocks[last].n++
+		} else {
+			blocks = append(blocks, block{lo: s, hi: s, sum: y, n: 1})
+		}
+		for len(blocks) > 1 {
+			prev, cur := blocks[len(blocks)-2], blocks[len(blocks)-1]
+			if mean(prev) <= mean(cur) {
+				break
+			}
+			blocks = append(blocks[:len(blocks)-2], block{lo: prev.lo, hi: cur.hi, sum: prev.sum + cur.sum, n: prev.n + cur.n})
+		}
+	}
+
+	// 每段的两个端点作为节点，段内取常数，相邻两段之间线性插值
+	c.X, c.Y = c.X[:0], c.Y[:0]
+	for _, b := range blocks {
+		c.X = append(c.X, b.lo)
+		c.Y = append(c.Y, mean(b))
+		if b.hi != b.lo {
+			c.X = append(c.X, b.hi)
+			c.Y = append(c.Y, mean(b))
+		}
+	}
+	return nil
+}
+
+func (c *Isotonic) Transform(scores []float64) []float64 {
+	prob := make([]float64, len(scores))
+	last := len(c.X) - 1
+	for i, s := range scores {
+		k := sort.SearchFloat64s(c.X, s)
+		switch {
+		case k == 0:
+			prob[i] = c.Y[0]
+		case k > last:
+			prob[i] = c.Y[last]
+		default:
+			t := (s - c.X[k-1]) / (c.X[k] - c.X[k-1])
+			prob[i] = c.Y[k-1] + t*(c.Y[k]-c.Y[k-1])
+		}
+	}
+	return prob
+}
+
+// Scorer 返回每个样本的得分，得分越大越可能是正类；校准器只依赖得分的大小关系和取值
+type Scorer func(X mat.Matrix) []float64
+
+// Logistic 手写逻辑回归模型 (b, w) 的得分，即决策值 b + w·x（log-odds）
+func Logistic(b float64, w []float64) Scorer {
+	return func(X mat.Matrix) []float64 { return logreg.Decision(b, w, X) }
+}
+
+// Probaser 按类别输出概率的模型，例如 pa-m/sklearn 的 linearmodel.LogisticRegression
+type Probaser interface {
+	PredictProbas(X mat.Matrix, Y mat.Mutable) *mat.Dense
+}
+
+// FromProbas 把模型输出的正类概率（最后一列）换算成 log-odds 作为得分，
+// 使 Platt 缩放的输入与 Logistic 相同；概率先截断到 [1e-15, 1-1e-15] 以免得到无穷大
+func FromProbas(m Probaser) Scorer {
+	const eps = 1e-15
+	return func(X mat.Matrix) []float64 {
+		P := m.PredictProbas(X, nil)
+		n, cols := P.Dims()
+		scores := make([]float64, n)
+		for i := range scores {
+			scores[i] = logreg.Logit(math.Min(math.Max(P.At(i, cols-1), eps), 1-eps))
+		}
+		return scores
+	}
+}
+
+// Classifier 包装一个训练好的分类器和拟合好的校准器
+type Classifier struct {
+	Scorer     Scorer
+	Calibrator Calibrator
+}
+
+// Calibrate 在 (X, yTrue) 上拟合校准器并与 scorer 组合；X 不应包含训练 scorer 所用的样本
+func Calibrate(scorer Scorer, c Calibrator, X mat.Matrix, yTrue []int) (*Classifier, error) {
+	if err := c.Fit(scorer(X), yTrue); err != nil {
+		return nil, err
+	}
+	return &Classifier{Scorer: scorer, Calibrator: c}, nil
+}
+
+// PredictProba 返回校准后的正类概率
+func (c *Classifier) PredictProba(X mat.Matrix) []float64 {
+	return c.Calibrator.Transform(c.Scorer(X))
+}
+
+// CrossCalibrate 对每个 split 只用训练折的得分拟合一个新的校准器，再变换测试折的得分，
+// 返回每个样本在没有参与拟合时得到的校准概率，用来公平地评估校准效果。
+// scores 应当是折外得分，即每个样本的得分来自没有见过它的模型
+func CrossCalibrate(name string, splits []cv.Split, scores []float64, yTrue []int) ([]float64, error) {
+	prob := make([]float64, len(scores))
+	for _, s := range splits {
+		c, err := New(name)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.Fit(cv.Floats(scores, s.Train), cv.Ints(yTrue, s.Train)); err != nil {
+			return nil, err
+		}
+		for k, p := range c.Transform(cv.Floats(scores, s.Test)) {
+			prob[s.Test[k]] = p
+		}
+	}
+	return prob, nil
+}
@@ -0,0 +1,97 @@
+package calibration
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+
+	"ai/logreg"
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-12
+}
+
+func TestIsotonicPooled(t *testing.T) {
+	// 手算：按得分排序后标签为 1 0 0 | 1 0 0 | 1 1（得分 4 和 6 各有两个样本）。
+	// 得分 1~3 合并成均值 1/3 的一段；得分 4 的两个样本均值 1/2，再与得分 5 合并成均值 1/3 的一段；
+	// 这一段与前一段均值相等，不违反单调性，保持两段
+	scores := []float64{4, 1, 6, 3, 5, 2, 4, 6}
+	yTrue := []int{1, 1, 1, 0, 0, 0, 0, 1}
+	var c Isotonic
+	if err := c.Fit(scores, yTrue); err != nil {
+		t.Fatal(err)
+	}
+	wantX := []float64{1, 3, 4, 5, 6}
+	wantY := []float64{1.0 / 3, 1.0 / 3, 1.0 / 3, 1.0 / 3, 1}
+	if !slices.Equal(c.X, wantX) || !slices.EqualFunc(c.Y, wantY, near) {
+		t.Errorf("节点为 X=%v Y=%v，应为 X=%v Y=%v", c.X, c.Y, wantX, wantY)
+	}
+
+	// 节点之间线性插值，超出范围取端点的值
+	got := c.Transform([]float64{0, 2, 5.5, 7})
+	if want := []float64{1.0 / 3, 1.0 / 3, 2.0 / 3, 1}; !slices.EqualFunc(got, want, near) {
+		t.Errorf("Transform 得到 %v，应为 %v", got, want)
+	}
+}
+
+func TestIsotonicMonotone(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 20; trial++ {
+		n := 1 + rng.Intn(200)
+		scores := make([]float64, n)
+		yTrue := make([]int, n)
+		for i := range scores {
+			// 取整制造并列的得分，标签与得分正相关但有很多违反单调性的地方
+			scores[i] = math.Round(rng.NormFloat64() * 10)
+			if rng.Float64() < logreg.Sigmoid(scores[i]/10) {
+				yTrue[i] = 1
+			}
+		}
+		var c Isotonic
+		if err := c.Fit(scores, yTrue); err != nil {
+			t.Fatal(err)
+		}
+		if !sort.Float64sAreSorted(c.X) || !sort.Float64sAreSorted(c.Y) {
+			t.Errorf("n=%d: 节点不是单调不减的：X=%v Y=%v", n, c.X, c.Y)
+		}
+		grid := make([]float64, 101)
+		for i := range grid {
+			grid[i] = -50 + float64(i)
+		}
+		prob := c.Transform(grid)
+		if !sort.Float64sAreSorted(prob) || prob[0] < 0 || prob[len(prob)-1] > 1 {
+			t.Errorf("n=%d: 校准后的概率不是 [0, 1] 内单调不减的：%v", n, prob)
+		}
+	}
+}
+
+func TestPlattRecovers(t *testing.T) {
+	// 标签按 P(y=1) = σ(A·s + B) 生成，样本足够多时拟合结果应接近真实的 A、B
+	const (
+		trueA, trueB = 2.0, -1.0
+		n            = 20000
+	)
+	rng := rand.New(rand.NewSource(1))
+	scores := make([]float64, n)
+	yTrue := make([]int, n)
+	for i := range scores {
+		scores[i] = rng.NormFloat64() * 2
+		if rng.Float64() < logreg.Sigmoid(trueA*scores[i]+trueB) {
+			yTrue[i] = 1
+		}
+	}
+	var p Platt
+	if err := p.Fit(scores, yTrue); err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(p.A-trueA) > 0.1 || math.Abs(p.B-trueB) > 0.1 {
+		t.Errorf("拟合得到 A=%.4f B=%.4f，应接近 A=%g B=%g", p.A, p.B, trueA, trueB)
+	}
+
+	if err := p.Fit(scores, yTrue[:n-1]); err == nil {
+		t.Error("Fit 没有拒绝长度不一致的得分和标签")
+	}
+}
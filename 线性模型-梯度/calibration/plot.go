@@ -0,0 +1,60 @@
+package calibration
+
+import (
+	"fmt"
+	"image/color"
+
+	"ai/metrics"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// 每条可靠性曲线的颜色，依次用于 reports 中的各项
+var curveColors = []color.RGBA{
+	{R: 0, G: 0, B: 255, A: 255},
+	{R: 255, G: 0, B: 0, A: 255},
+	{R: 0, G: 160, B: 0, A: 255},
+	{R: 255, G: 165, B: 0, A: 255},
+}
+
+// ReliabilityPlot 画出可靠性图：横轴是区间内的平均预测概率，纵轴是实际正类比例，
+// 虚线对角线表示完美校准，每个 report 一条折线，图例中附上 Brier 分数和 ECE
+func ReliabilityPlot(reports []metrics.CalibrationReport) (*plot.Plot, error) {
+	p := plot.New()
+	p.Title.Text = "Reliability Diagram"
+	p.X.Label.Text = "Mean Predicted Probability"
+	p.Y.Label.Text = "Fraction of Positives"
+	p.X.Min, p.X.Max = 0, 1
+	p.Y.Min, p.Y.Max = 0, 1
+	p.Legend.Top = false
+
+	diagonal, err := plotter.NewLine(plotter.XYs{{X: 0, Y: 0}, {X: 1, Y: 1}})
+	if err != nil {
+		return nil, err
+	}
+	diagonal.Color = color.RGBA{R: 0, G: 0, B: 0, A: 255}
+	diagonal.Dashes = []vg.Length{vg.Points(5), vg.Points(5)}
+	p.Add(diagonal)
+	p.Legend.Add("Perfectly Calibrated", diagonal)
+
+	for k, r := range reports {
+		pts := make(plotter.XYs, len(r.Curve))
+		for i, b := range r.Curve {
+			pts[i] = plotter.XY{X: b.MeanProb, Y: b.FracPos}
+		}
+		line, points, err := plotter.NewLinePoints(pts)
+		if err != nil {
+			return nil, err
+		}
+		c := curveColors[k%len(curveColors)]
+		line.Color = c
+		line.Width = vg.Points(2)
+		points.Color = c
+		points.Radius = vg.Points(3)
+		p.Add(line, points)
+		p.Legend.Add(fmt.Sprintf("%s (%s)", r.Name, r.Summary()), line, points)
+	}
+	return p, nil
+}
@@ -0,0 +1,176 @@
+// Package cluster 实现二维数据点上的 k-means 和均值漂移聚类。
+//
+// 两种算法都以 Step 为单位推进，每调用一次执行一轮迭代，
+// 便于可视化程序在两次迭代之间插入动画。
+package cluster
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Point 二维数据点
+type Point struct {
+	X, Y float64
+}
+
+// Distance 计算欧氏距离
+func Distance(p1, p2 Point) float64 {
+	return math.Hypot(p1.X-p2.X, p1.Y-p2.Y)
+}
+
+// KMeans k-means 聚类的状态
+type KMeans struct {
+	Points    []Point // 所有数据点
+	Labels    []int   // 当前聚类结果
+	Centroids []Point // 当前聚类中心
+	Iteration int     // 已执行的迭代次数
+}
+
+// NewKMeans 从 points 中随机选取 k 个点作为初始聚类中心，所有点最初都归入第 0 类
+func NewKMeans(points []Point, k int, rnd *rand.Rand) *KMeans {
+	centroids := make([]Point, k)
+	for i := range centroids {
+		centroids[i] = points[rnd.Intn(len(points))]
+	}
+	return &KMeans{
+		Points:    points,
+		Labels:    make([]int, len(points)),
+		Centroids: centroids,
+	}
+}
+
+// K 返回聚类数量
+func (km *KMeans) K() int {
+	return len(km.Centroids)
+}
+
+// Step 执行一次 k-means 迭代，返回是否有点改变了所属的聚类；返回 false 即已收敛
+func (km *KMeans) Step() bool {
+	changed := false
+
+	// 1. 分配每个点到最近的聚类中心
+	for i, p := range km.Points {
+		minDist := math.MaxFloat64
+		closest := km.Labels[i]
+
+		for j, c := range km.Centroids {
+			dist := Distance(p, c)
+			if dist < minDist {
+				minDist = dist
+				closest = j
+			}
+		}
+
+		if closest != km.Labels[i] {
+			km.Labels[i] = closest
+			changed = true
+		}
+	}
+
+	// 2. 更新聚类中心为每个聚类的平均值
+	newCentroids := make([]Point, km.K())
+	counts := make([]int, km.K())
+
+	for i, c := range km.Labels {
+		newCentroids[c].X += km.Points[i].X
+		newCentroids[c].Y += km.Points[i].Y
+		counts[c]++
+	}
+
+	for j := range newCentroids {
+		if counts[j] > 0 {
+			newCentroids[j].X /= float64(counts[j])
+			newCentroids[j].Y /= float64(counts[j])
+		}
+	}
+
+	km.Centroids = newCentroids
+	km.Iteration++
+
+	return changed
+}
+
+// MeanShift 均值漂移聚类的状态
+type MeanShift struct {
+	Points     []Point // 原始数据点
+	Modes      []Point // 每个点的漂移终点（模式点）
+	Labels     []int   // 聚类标签，Merge 之后才有意义
+	Centers    []Point // 合并后的聚类中心，Merge 之后才有值
+	Bandwidth  float64 // 带宽（核函数半径）
+	Iterations int     // 已执行的迭代次数
+}
+
+// NewMeanShift 以原始点作为漂移起点
+func NewMeanShift(points []Point, bandwidth float64) *MeanShift {
+	ms := &MeanShift{
+		Points:    points,
+		Modes:     make([]Point, len(points)),
+		Labels:    make([]int, len(points)),
+		Bandwidth: bandwidth,
+	}
+	copy(ms.Modes, points)
+	return ms
+}
+
+// Step 执行一步均值漂移计算，所有模式点的移动距离都小于 0.01 时返回 true
+func (ms *MeanShift) Step() bool {
+	converged := true
+	bandwidthSq := ms.Bandwidth * ms.Bandwidth // 带宽平方（优化计算）
+
+	// 对每个点执行一次漂移计算
+	for i := range ms.Modes {
+		currentMode := ms.Modes[i]
+		sumX, sumY := 0.0, 0.0
+		totalWeight := 0.0
+
+		// 计算带宽范围内的加权平均
+		for _, p := range ms.Points {
+			distSq := (p.X-currentMode.X)*(p.X-currentMode.X) + (p.Y-currentMode.Y)*(p.Y-currentMode.Y)
+			if distSq <= bandwidthSq {
+				// 高斯核函数权重
+				weight := math.Exp(-distSq / (2 * bandwidthSq))
+				sumX += p.X * weight
+				sumY += p.Y * weight
+				totalWeight += weight
+			}
+		}
+
+		// 计算新的模式点
+		if totalWeight > 0 {
+			newMode := Point{
+				X: sumX / totalWeight,
+				Y: sumY / totalWeight,
+			}
+
+			// 检查是否收敛（移动距离小于阈值）
+			if Distance(newMode, currentMode) > 0.01 {
+				ms.Modes[i] = newMode
+				converged = false
+			}
+		}
+	}
+
+	ms.Iterations++
+	return converged
+}
+
+// Merge 把距离小于带宽一半的模式点合并为同一个聚类，计算 Labels 和 Centers
+func (ms *MeanShift) Merge() {
+	ms.Centers = ms.Centers[:0]
+	for i := range ms.Labels {
+		found := false
+		// 检查是否与已有聚类中心相似
+		for j, center := range ms.Centers {
+			if Distance(ms.Modes[i], center) < ms.Bandwidth/2 {
+				ms.Labels[i] = j
+				found = true
+				break
+			}
+		}
+		if !found {
+			ms.Centers = append(ms.Centers, ms.Modes[i])
+			ms.Labels[i] = len(ms.Centers) - 1
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package cluster
+
+import (
+	"fmt"
+
+	"ai/datasets"
+	"ai/model"
+	"ai/scale"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// FromTable 取出文件数据的前两个特征列，并把每列线性缩放到 [5, 95]，
+// 使任意取值范围的数据都能按 0-100 的坐标系显示在窗口中；同时返回这个缩放变换，
+// 用于把聚类结果换算回原始单位
+func FromTable(t datasets.Table) ([]Point, scale.Affine, error) {
+	rows, cols := t.X.Dims()
+	if cols < 2 {
+		return nil, scale.Affine{}, fmt.Errorf("cluster: 二维聚类至少需要 2 个特征列，实际为 %v", t.FeatureNames)
+	}
+	// x' = 5 + 90*(x-lo)/(hi-lo)，写成 (x - center) / scale 的形式
+	view := scale.Affine{Center: make([]float64, 2), Scale: make([]float64, 2)}
+	for j := 0; j < 2; j++ {
+		col := mat.Col(nil, j, t.X)
+		lo, hi := floats.Min(col), floats.Max(col)
+		if hi == lo {
+			hi = lo + 1
+		}
+		view.Scale[j] = (hi - lo) / 90
+		view.Center[j] = lo - 5*view.Scale[j]
+	}
+	return FromMatrix(view.Transform(t.X.Slice(0, rows, 0, 2))), view, nil
+}
+
+// Identity 不做变换的 view，用于本身就在 0-100 坐标系中的合成数据
+func Identity() scale.Affine {
+	return scale.Affine{Center: []float64{0, 0}, Scale: []float64{1, 1}}
+}
+
+// FromMatrix 把 X 的前两列转换成数据点
+func FromMatrix(X mat.Matrix) []Point {
+	rows, _ := X.Dims()
+	points := make([]Point, rows)
+	for i := range points {
+		points[i] = Point{X: X.At(i, 0), Y: X.At(i, 1)}
+	}
+	return points
+}
+
+// ToTable 把数据点转换成列为 x、y 的表，供 -export 导出和计算数据指纹
+func ToTable(points []Point) datasets.Table {
+	X := mat.NewDense(len(points), 2, nil)
+	for i, p := range points {
+		X.Set(i, 0, p.X)
+		X.Set(i, 1, p.Y)
+	}
+	return datasets.Table{X: X, FeatureNames: []string{"x", "y"}}
+}
+
+// NewModel 把窗口坐标下的中心点用 view 换算回原始单位，连同训练数据 table 的指纹一起组成可保存的模型
+func NewModel(kind model.Kind, centers []Point, view scale.Affine, table datasets.Table, hyper map[string]interface{}) *model.Model {
+	C := mat.NewDense(len(centers), 2, nil)
+	for k, c := range centers {
+		C.Set(k, 0, c.X)
+		C.Set(k, 1, c.Y)
+	}
+	C = view.InverseTransform(C)
+	rows, _ := table.X.Dims()
+	m := &model.Model{
+		Kind:        kind,
+		Features:    table.FeatureNames[:2],
+		Hyperparams: hyper,
+		Data:        model.NewFingerprint(table.X.Slice(0, rows, 0, 2), nil),
+	}
+	for k := range centers {
+		m.Centers = append(m.Centers, mat.Row(nil, k, C))
+	}
+	return m
+}
@@ -0,0 +1,125 @@
+// Package basiscmd 实现 ai basis 子命令：用多项式、RBF 或傅里叶特征展开拟合曲线
+package basiscmd
+
+import (
+	"flag"
+	"fmt"
+	"image/color"
+	"log"
+	"math"
+
+	"ai/datasets"
+	"ai/features"
+	"ai/linreg"
+	"ai/optim"
+	"ai/rng"
+	"ai/train"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+var (
+	fs        = flag.NewFlagSet("basis", flag.ExitOnError)
+	basisName = fs.String("basis", "poly", "特征展开：poly, rbf, fourier")
+	degree    = fs.Int("degree", 5, "多项式的最高次数")
+	count     = fs.Int("count", 10, "RBF 中心个数或傅里叶阶数")
+	epochs    = fs.Int("epochs", 5000, "梯度下降的迭代次数")
+	seedFlag  = fs.Int64("seed", 1, rng.FlagUsage)
+	dataSize  = fs.Int("n", 300, "样本数")
+	lrFlag    = fs.Float64("lr", 0.05, "Adam 的学习率")
+	sigma     = fs.Float64("sigma", 0.5, "加在真实曲线上的高斯噪声的标准差")
+)
+
+// 数据范围
+const (
+	xMin = -5.0
+	xMax = 5.0
+)
+
+// trueCurve 生成数据所用的真实曲线
+func trueCurve(x float64) float64 {
+	return 3*math.Sin(x) + 0.5*x
+}
+
+// Main 运行子命令，args 是子命令之后的命令行参数
+func Main(args []string) {
+	fs.Parse(args)
+	src := rng.Source(rng.Setup(*seedFlag))
+
+	// 在真实曲线上加高斯噪声生成样本
+	data := datasets.MakeCurve(src, *dataSize, xMin, xMax, trueCurve, datasets.Gaussian{Sigma: *sigma}).Points()
+
+	expander, err := features.New(*basisName, *degree, *count, xMin, xMax)
+	if err != nil {
+		log.Fatal(err)
+	}
+	X, y := linreg.PointsToMatrix(data)
+	Phi := features.Transform(expander, X)
+
+	// 闭式解作为参照
+	exactB, exactW, err := linreg.SolveMulti(Phi, y, linreg.QR)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 梯度下降拟合同一组展开特征
+	cfg := train.Config{LR: *lrFlag, Epochs: *epochs, Optimizer: optim.NewAdam(optim.DefaultBeta1, optim.DefaultBeta2, optim.DefaultEpsilon), LogEvery: 500}
+	b, w, _ := linreg.GradientDescentMulti(Phi, y, 0, nil, nil, cfg)
+
+	fmt.Printf("Basis: %s, features: %d\n", *basisName, expander.NumOutputs())
+	fmt.Printf("loss: exact=%f, gd=%f\n", linreg.MseMulti(exactB, exactW, Phi, y), linreg.MseMulti(b, w, Phi, y))
+	fmt.Printf("  %-12s exact=%10.4f gd=%10.4f\n", "bias", exactB, b)
+	for j, name := range expander.Names() {
+		fmt.Printf("  %-12s exact=%10.4f gd=%10.4f\n", name, exactW[j], w[j])
+	}
+
+	plotCurves(data, expander, exactB, exactW, b, w)
+}
+
+// plotCurves 画出样本点、真实曲线、闭式解曲线和梯度下降曲线
+func plotCurves(data [][]float64, expander features.Expander, exactB float64, exactW []float64, b float64, w []float64) {
+	p := plot.New()
+	p.Title.Text = fmt.Sprintf("Basis Function Regression (%s)", *basisName)
+	p.X.Label.Text = "X"
+	p.Y.Label.Text = "Y"
+
+	points := make(plotter.XYs, len(data))
+	for i, d := range data {
+		points[i] = plotter.XY{X: d[0], Y: d[1]}
+	}
+	scatter, err := plotter.NewScatter(points)
+	if err != nil {
+		panic(err)
+	}
+	scatter.Color = color.RGBA{R: 255, A: 255}
+	scatter.Radius = vg.Points(2)
+	p.Add(scatter)
+	p.Legend.Add("Samples", scatter)
+
+	curves := []struct {
+		name string
+		f    func(float64) float64
+		c    color.Color
+	}{
+		{"True Curve", trueCurve, color.RGBA{B: 255, A: 255}},
+		{"Closed Form", features.Curve(expander, exactB, exactW), color.RGBA{G: 180, A: 255}},
+		{"Gradient Descent", features.Curve(expander, b, w), color.RGBA{R: 165, G: 120, B: 32, A: 255}},
+	}
+	for _, c := range curves {
+		fn := plotter.NewFunction(c.f)
+		fn.XMin, fn.XMax = xMin, xMax
+		fn.Samples = 200
+		fn.Color = c.c
+		fn.Width = vg.Points(2)
+		p.Add(fn)
+		p.Legend.Add(c.name, fn)
+	}
+	p.Legend.Top = true
+
+	if err := p.Save(10*vg.Inch, 8*vg.Inch, "basis_fit.png"); err != nil {
+		panic(err)
+	}
+	fmt.Println("图像已保存为 basis_fit.png")
+}
@@ -1,12 +1,18 @@
-package main
+// Package diabetescmd 实现 ai sgd-diabetes 子命令：在 diabetes 数据集上训练 pa-m/sklearn 的 SGDRegressor
+package diabetescmd
 
 import (
+	"flag"
 	"image/color"
  
 	"time"
 	"os/exec"
 
  "fmt"
+	"ai/cv"
+	"ai/metrics"
+	"ai/rng"
+
 	"github.com/pa-m/sklearn/datasets"
 	"github.com/pa-m/sklearn/linear_model"
  
@@ -16,7 +22,22 @@ import (
 	"gonum.org/v1/plot/vg"
 )
 
-func main() {
+var (
+	fs        = flag.NewFlagSet("sgd-diabetes", flag.ExitOnError)
+	seedFlag  = fs.Int64("seed", 1, rng.FlagUsage)
+	testRatio = fs.Float64("test-ratio", 0.1, "打乱后留作测试集的样本比例")
+	cvMethod  = fs.String("cv", "kfold", fmt.Sprintf("交叉验证方式，可选：%v（回归没有类别，不能分层）", cv.Methods()))
+	numFolds  = fs.Int("folds", 5, "交叉验证的折数，<2 时不做交叉验证")
+	alpha     = fs.Float64("alpha", 0.001, "SGDRegressor 的 Alpha（正则化强度）")
+	numJobs   = fs.Int("jobs", 10000, "SGDRegressor 的 NJobs")
+)
+
+// Main 运行子命令，args 是子命令之后的命令行参数
+func Main(args []string) {
+	fs.Parse(args)
+	// SGDRegressor 用全局随机源初始化系数，Setup 会为它设置种子
+	seed := rng.Setup(*seedFlag)
+
 	// Load the diabetes dataset
 	diabetes := datasets.LoadDiabetes()
 
@@ -27,35 +48,51 @@ func main() {
 
  
 
-	// Split the data into training/testing sets (last 20 samples as test set)
-	diabetesXtrain := diabetesX.Slice(0, NSamples-20, 0, 1).(*mat.Dense)
-	diabetesXtest := diabetesX.Slice(NSamples-20, NSamples, 0,1).(*mat.Dense)
+	// Split the data into training/testing sets (shuffled, --test-ratio of the samples as test set)
+	split := cv.TrainTestSplit(rng.New(seed), NSamples, *testRatio)
+	diabetesXtrain := cv.Rows(diabetesX, split.Train)
+	diabetesXtest := cv.Rows(diabetesX, split.Test)
 
 	// Split the targets into training/testing sets
-	diabetesYtrain := diabetes.Y.Slice(0, NSamples-20, 0, 1).(*mat.Dense)
-	diabetesYtest := diabetes.Y.Slice(NSamples-20, NSamples, 0, 1).(*mat.Dense)
+	diabetesYtrain := cv.Rows(diabetes.Y, split.Train)
+	diabetesYtest := cv.Rows(diabetes.Y, split.Test)
 
 	// Create linear regression object
  	///regr := linearmodel.NewLinearRegression()
 
 
     regr := linearmodel.NewSGDRegressor()
-   regr.NJobs                = 10000    // 最大迭代次数
-    regr.Alpha = 0.001       // 初始学习率
+	regr.NJobs = *numJobs
+	regr.Alpha = *alpha
+
 
+	// Cross-validate on all samples and report mean ± std of R² across folds
+	if *numFolds >= 2 {
+		splits, err := cv.Splits(*cvMethod, rng.New(seed), NSamples, nil, *numFolds)
+		if err != nil {
+			panic(err)
+		}
+		if *cvMethod == "loo" {
+			// 留一法每折只有一个测试样本，单个样本上的 R² 没有定义，改为在全部折外预测上计算一次
+			pred := cv.PredictOutOfFold(regr, diabetesX, diabetes.Y, splits)
+			fmt.Printf("loo cross-validation R² (out-of-fold predictions): %.4f\n", metrics.R2(mat.Col(nil, 0, diabetes.Y), mat.Col(nil, 0, pred)))
+		} else {
+			fmt.Printf("%s cross-validation R²: %v\n", *cvMethod, cv.CrossValidate(splits, cv.PredicterFold(regr, diabetesX, diabetes.Y)))
+		}
+	}
 
 	// Train the model using the training sets
 	regr.Fit(diabetesXtrain, diabetesYtrain)
+	fmt.Printf("Test R²: %.4f (%d train, %d test samples)\n", regr.Score(diabetesXtest, diabetesYtest), len(split.Train), len(split.Test))
 
 	// Make predictions using the testing set
-	NTestSamples := 20
+	NTestSamples := len(split.Test)
 	diabetesYpred := mat.NewDense(NTestSamples, 1, nil)
 	regr.Predict(diabetesXtest, diabetesYpred)
 
 	// Print coefficients
 	fmt.Printf("Coefficients: %.8f\n", mat.Formatted(regr.Coef))
-	fmt.Printf("Coefficients: %.8f\n",  regr.Coef) 
-	fmt.Printf("Coefficients: %.8f\n",  regr.Intercept) 
+	fmt.Printf("Intercept: %.8f\n", mat.Formatted(regr.Intercept))
 
  
 
@@ -0,0 +1,51 @@
+// Package gradcheckcmd 实现 ai gradcheck 子命令：计算逻辑回归的损失和梯度，并用中心差分检查梯度
+package gradcheckcmd
+
+import (
+    "flag"
+    "fmt"
+
+    "ai/gradcheck"
+    "ai/logreg"
+
+    "gonum.org/v1/gonum/mat"
+)
+
+var fs = flag.NewFlagSet("gradcheck", flag.ExitOnError)
+
+// Main 运行子命令，args 是子命令之后的命令行参数
+func Main(args []string) {
+    fs.Parse(args)
+
+    // 示例数据（3 个样本，每个样本 2 个特征）
+    yTrue := []int{1, 0, 1}
+    X := mat.NewDense(3, 2, []float64{
+        1.0, 1.0,
+        2.0, 2.0,
+        3.0, 3.0,
+    })
+    b, w := 0.0, []float64{0.0, 0.0} // 初始参数
+
+    // 计算初始损失
+    loss := logreg.CrossEntropyLoss(b, w, X, yTrue)
+    fmt.Printf("初始损失: J=%.4f\n", loss)
+
+    // 计算梯度
+    gradB, gradW := logreg.Gradient(b, w, X, yTrue)
+
+    fmt.Printf("对 b 的梯度: dJ/db=%.4f\n", gradB)
+    for j, g := range gradW {
+        fmt.Printf("对 w[%d] 的梯度: dJ/dw%d=%.4f\n", j, j, g)
+    }
+
+    // 用中心差分验证手工推导的梯度，参数按 [b, w...] 排列
+    res := gradcheck.Check(
+        func(p []float64) float64 { return logreg.CrossEntropyLoss(p[0], p[1:], X, yTrue) },
+        func(p []float64) []float64 {
+            gb, gw := logreg.Gradient(p[0], p[1:], X, yTrue)
+            return append([]float64{gb}, gw...)
+        },
+        append([]float64{b}, w...), gradcheck.DefaultEps,
+    )
+    fmt.Printf("梯度检查（中心差分），最大相对误差 %.3g：\n%v", res.MaxRelErr(), res)
+}
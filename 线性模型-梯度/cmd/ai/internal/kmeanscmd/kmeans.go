@@ -0,0 +1,208 @@
+// Package kmeanscmd 实现 ai kmeans 子命令：用 Ebiten 动画展示 k-means 聚类的迭代过程
+package kmeanscmd
+
+import (
+	"flag"
+	"fmt"
+	"image/color"
+	"log"
+	"math/rand"
+
+	"ai/cluster"
+	"ai/datasets"
+	"ai/model"
+	"ai/rng"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	exprand "golang.org/x/exp/rand"
+)
+
+// 生成随机点：在 [min, max) 的正方形内均匀采样
+func generateRandomPoints(src exprand.Source, count int, min, max float64) []cluster.Point {
+	X := datasets.MakeUniform(src, count, 2, min, max)
+	points := make([]cluster.Point, count)
+	for i := range points {
+		points[i] = cluster.Point{X: X.At(i, 0), Y: X.At(i, 1)}
+	}
+	return points
+}
+
+// 可视化窗口和动画逻辑
+type Game struct {
+	km            *cluster.KMeans // 聚类状态（数据点、聚类结果、聚类中心和迭代次数）
+	prevCentroids []cluster.Point // 上一轮聚类中心（用于动画过渡）
+	width         int             // 窗口宽度
+	height        int             // 窗口高度
+	animProgress  float64         // 动画进度（0-1）
+	animSpeed     float64         // 动画速度
+	converged     bool            // 是否收敛
+	onConverged   func(*Game)     // 收敛时调用一次，为 nil 时不调用
+}
+
+func NewGame(points []cluster.Point, k int, rnd *rand.Rand) *Game {
+	return &Game{
+		km:            cluster.NewKMeans(points, k, rnd), // 初始化聚类中心
+		prevCentroids: make([]cluster.Point, k),
+		width:         800,
+		height:        600,
+		animProgress:  0,
+		animSpeed:     0.01, // 每次更新的动画进度增量
+		converged:     false,
+	}
+}
+
+func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return g.width, g.height
+}
+
+// 执行一次K-means迭代
+func (g *Game) stepKmeans() bool {
+	// 保存当前中心作为上一轮中心（用于动画过渡）
+	copy(g.prevCentroids, g.km.Centroids)
+	return g.km.Step()
+}
+
+func (g *Game) Update() error {
+	if g.converged {
+		return nil
+	}
+	
+	// 动画进行中，更新进度
+	if g.animProgress < 1.0 {
+		g.animProgress += g.animSpeed
+		if g.animProgress > 1.0 {
+			g.animProgress = 1.0
+		}
+		return nil
+	}
+	
+	// 动画完成，执行下一步K-means迭代
+	changed := g.stepKmeans()
+	if !changed {
+		g.converged = true
+		if g.onConverged != nil {
+			g.onConverged(g)
+		}
+	}
+	g.animProgress = 0 // 重置动画进度
+	
+	return nil
+}
+
+func (g *Game) Draw(screen *ebiten.Image) {
+	// 填充背景为白色
+	screen.Fill(color.White)
+
+	// 定义聚类颜色
+	colors := []color.Color{
+		color.RGBA{255, 0, 0, 255},    // 红色
+		color.RGBA{0, 255, 0, 255},    // 绿色
+		color.RGBA{0, 0, 255, 255},    // 蓝色
+		color.RGBA{255, 165, 0, 255},  // 橙色
+		color.RGBA{128, 0, 128, 255},  // 紫色
+		color.RGBA{0, 255, 255, 255},  // 青色
+		color.RGBA{255, 255, 0, 255},  // 黄色
+		color.RGBA{128, 128, 128, 255}, // 灰色
+		color.RGBA{18, 218, 18, 255},  
+		color.RGBA{181, 28, 8, 255}, 
+		color.RGBA{81, 32, 48, 255},  
+		color.RGBA{251,54, 88, 255},  
+	}
+
+	// 绘制所有点（按聚类颜色区分）
+	for i, p := range g.km.Points {
+		clusterID := g.km.Labels[i]
+		c := colors[clusterID%len(colors)]
+
+		// 坐标映射到窗口尺寸
+		x := int(p.X * float64(g.width) / 100)
+		y := int(p.Y * float64(g.height) / 100)
+
+		// 绘制点（4x4的方块）
+		for dx := -2; dx <= 2; dx++ {
+			for dy := -2; dy <= 2; dy++ {
+				screen.Set(x+dx, y+dy, c)
+			}
+		}
+	}
+
+	// 绘制聚类中心（带动画过渡效果）
+	for i := 0; i < g.km.K(); i++ {
+		// 计算动画过渡中的中心位置
+		x := g.prevCentroids[i].X + (g.km.Centroids[i].X-g.prevCentroids[i].X)*g.animProgress
+		y := g.prevCentroids[i].Y + (g.km.Centroids[i].Y-g.prevCentroids[i].Y)*g.animProgress
+		
+		// 映射到窗口坐标
+		screenX := int(x * float64(g.width) / 100)
+		screenY := int(y * float64(g.height) / 100)
+
+		// 绘制中心（8x8的黑色方块）
+		for dx := -4; dx <= 4; dx++ {
+			for dy := -4; dy <= 4; dy++ {
+				screen.Set(screenX+dx, screenY+dy, color.Black)
+			}
+		}
+	}
+
+	// 显示迭代信息
+	status := fmt.Sprintf("K-means 聚类动画 (k=%d) - 迭代次数: %d", g.km.K(), g.km.Iteration)
+	if g.converged {
+		status += " - 已收敛！"
+	}
+	ebitenutil.DebugPrint(screen, status)
+}
+
+var (
+	fs       = flag.NewFlagSet("kmeans", flag.ExitOnError)
+	seedFlag = fs.Int64("seed", 1, rng.FlagUsage)
+	dataSize = fs.Int("n", 300, "合成数据的点数")
+	numK     = fs.Int("k", 5, "聚类数量")
+	csvFlags = datasets.RegisterCSVFlags(fs, "")
+	savePath = fs.String("save", "", "收敛后把聚类中心保存到该 JSON 文件，可用 ai predict 加载")
+)
+
+// Main 运行 k-means 聚类动画，args 是子命令之后的命令行参数
+func Main(args []string) {
+	fs.Parse(args)
+	seed := rng.Setup(*seedFlag)
+	// 在 0-100 的正方形内生成随机点
+	points := generateRandomPoints(rng.Source(seed), *dataSize, 0, 100)
+	table, loaded, err := csvFlags.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	// 合成数据本身就在 0-100 的坐标系中，窗口坐标与原始单位相同
+	view := cluster.Identity()
+	if loaded {
+		if points, view, err = cluster.FromTable(table); err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		table = cluster.ToTable(points)
+	}
+	if err := csvFlags.Save(table); err != nil {
+		log.Fatal(err)
+	}
+
+	// 初始化游戏（包含动画逻辑），聚类中心的初始化同样由 seed 决定
+	game := NewGame(points, *numK, rng.New(seed))
+	if *savePath != "" {
+		game.onConverged = func(g *Game) {
+			m := cluster.NewModel(model.KMeans, g.km.Centroids, view, table, map[string]interface{}{
+				"k": g.km.K(), "iterations": g.km.Iteration, "seed": seed,
+			})
+			if err := model.Save(*savePath, m); err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("模型已保存到 %s\n", *savePath)
+		}
+	}
+	ebiten.SetWindowSize(game.width, game.height)
+	ebiten.SetWindowTitle(fmt.Sprintf("K-means 聚类过程动画 (seed=%d)", seed))
+
+	// 运行动画
+	if err := ebiten.RunGame(game); err != nil {
+		panic(err)
+	}
+}
@@ -1,50 +1,54 @@
-package main
+// Package linedatacmd 实现 ai line-data 子命令：生成以直线为分界的二分类数据并画出数据分布
+package linedatacmd
 
 import (
+    "flag"
     "fmt"
     "image/color"
-    "math/rand"
-    "time"
+
+    "ai/datasets"
+    "ai/rng"
 
     "gonum.org/v1/plot"
     "gonum.org/v1/plot/plotter"
     "gonum.org/v1/plot/vg"
 )
 
-func main() {
-    // 设置随机数种子
-    rand.Seed(time.Now().UnixNano())
+var (
+    fs       = flag.NewFlagSet("line-data", flag.ExitOnError)
+    seedFlag = fs.Int64("seed", 1, rng.FlagUsage)
+    dataSize = fs.Int("n", 1000, "数据点数量")
+    noiseMax = fs.Float64("noise-max", 3.554646, "最大噪声值，y 在真实直线上加 [-noise-max, noise-max] 的均匀噪声")
+)
+
+// Main 运行子命令，args 是子命令之后的命令行参数
+func Main(args []string) {
+    fs.Parse(args)
+
+    seed := rng.Setup(*seedFlag)
 
     // 数据参数
     const (
-        n        = 1000    // 数据点数量
         xMin     = 0.0    // x最小值
         xMax     = 10.0   // x最大值
-        noiseMax = 3.554646    // 最大噪声值
     )
+    n, noiseMax := *dataSize, *noiseMax
 
     // 真实模型参数 y = 1.342x + 2.45
     slope := 1.342
     intercept := 2.45
 
-    // 生成数据
-    x := make([]float64, n)
-    y := make([]float64, n)
-    // 用于存储标识，1 表示在直线上方，0 表示在直线下方
-    labels := make([]int, n) 
-    for i := 0; i < n; i++ {
-        x[i] = xMin + rand.Float64()*(xMax-xMin)                  // x均匀分布
-        noise := (rand.Float64()*2 - 1) * noiseMax                 // 噪声范围: [-1.5, 1.5]
-        y[i] = slope*x[i] + intercept + noise                      // 带噪声的y值
-        
-        // 计算真实直线在该 x 处的 y 值
-        trueY := slope * x[i] + intercept
-        if y[i] > trueY {
-            labels[i] = 1
-        } else {
-            labels[i] = 0
-        }
-    }
+    // 生成数据：x 均匀分布，y 在真实直线上加 [-noiseMax, noiseMax] 的均匀噪声，
+    // 标识 1 表示在直线上方，0 表示在直线下方
+    data := datasets.MakeLine(rng.Source(seed), datasets.LineConfig{
+        Samples:   n,
+        Slope:     slope,
+        Intercept: intercept,
+        XMin:      xMin,
+        XMax:      xMax,
+        Noise:     datasets.Uniform{Min: -noiseMax, Max: noiseMax},
+    })
+    x, y, labels := data.XData, data.YData, data.Labels
 
     // 1. 修正 plot.New() 返回值：只返回1个对象和1个错误
     p  := plot.New()
@@ -0,0 +1,187 @@
+// Package linregcmd 实现 ai linreg 子命令：用梯度下降训练单特征线性回归
+package linregcmd
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"runtime"
+
+	"ai/datasets"
+	"ai/linreg"
+	"ai/loss"
+	"ai/metrics"
+	"ai/model"
+	"ai/optim"
+	"ai/rng"
+	"ai/train"
+)
+
+var (
+	fs            = flag.NewFlagSet("linreg", flag.ExitOnError)
+	optimizerName = fs.String("optimizer", "sgd", fmt.Sprintf("参数更新规则，可选：%v", optim.Names()))
+	batchSize     = fs.Int("batch", 0, "每步使用的样本数，0 为全批量，1 为随机梯度下降")
+	numEpochs     = fs.Int("epochs", 1000, "遍历全部样本的轮数，全批量时即迭代次数")
+	seedFlag      = fs.Int64("seed", 1, rng.FlagUsage)
+	scheduleName  = fs.String("schedule", "constant", fmt.Sprintf("学习率调度，可选：%v", optim.ScheduleNames()))
+	warmupSteps   = fs.Int("warmup", 0, "线性预热的步数，0 表示不预热")
+	lossTol       = fs.Float64("loss-tol", 0, "相邻两轮损失变化小于该值时停止，0 表示不启用")
+	gradTol       = fs.Float64("grad-tol", 0, "梯度范数小于该值时停止，0 表示不启用")
+	paramTol      = fs.Float64("param-tol", 0, "参数变化量小于该值时停止，0 表示不启用")
+	patience      = fs.Int("patience", 0, "连续多少轮损失没有改善时停止，0 表示不启用")
+	penaltyName   = fs.String("penalty", "", "正则化：ridge, lasso, elasticnet，为空时不正则化")
+	alpha         = fs.Float64("alpha", 0.01, "正则化强度")
+	l1Ratio       = fs.Float64("l1-ratio", 0.5, "elasticnet 中 L1 部分所占比例")
+	penalizeBias  = fs.Bool("penalize-intercept", false, "是否也惩罚偏置（截距）")
+	compareMethod = fs.String("compare", "", fmt.Sprintf("训练后与最小二乘闭式解对比，可选：%v，为空时不对比", linreg.Methods()))
+	lossName      = fs.String("loss", "mse", fmt.Sprintf("损失函数，可选：%v", loss.Names()))
+	lossParam     = fs.Float64("loss-param", 0, "huber 的 delta、tukey 的 c 或 quantile 的分位数，0 表示默认值")
+	outlierRatio  = fs.Float64("outliers", 0, "替换为离群点的样本比例，取值 [0, 1)")
+	noiseName     = fs.String("noise", "gaussian", fmt.Sprintf("目标值上的噪声分布，可选：%v", datasets.NoiseNames()))
+	workers       = fs.Int("workers", runtime.NumCPU(), "并行计算损失和梯度的 goroutine 数，1 为单线程")
+	csvFlags      = datasets.RegisterCSVFlags(fs, "y")
+	dataSize      = fs.Int("n", 50000, "合成数据的样本数")
+	lrFlag        = fs.Float64("lr", 0.01, "学习率")
+	noiseScale    = fs.Float64("sigma", 0.01, "目标值上噪声的幅度（高斯噪声的标准差）")
+	savePath      = fs.String("save", "", "把训练好的模型保存到该 JSON 文件，可用 ai predict 加载")
+)
+
+// Main 运行子命令，args 是子命令之后的命令行参数
+func Main(args []string) {
+	fs.Parse(args)
+	if *numEpochs < 1 {
+		log.Fatalf("-epochs 至少为 1，当前为 %d", *numEpochs)
+	}
+	seed := rng.Setup(*seedFlag)
+	opt, err := optim.New(*optimizerName)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 模拟样本数据：y = 1.477 * x + 0.089 + eps，x 在 -10 到 10 之间均匀采样，噪声幅度由 -sigma 指定（默认 0.01）；
+	// 离群点集中在 x 轴右侧、远低于真实直线，会把均方误差拟合的斜率往下拉。
+	// 指定 -data 时改为读取文件中的一个特征列和目标列
+	noise, err := datasets.NewNoise(*noiseName, *noiseScale)
+	if err != nil {
+		log.Fatal(err)
+	}
+	dataset := datasets.MakeRegression(rng.Source(seed), datasets.RegressionConfig{
+		Samples:   *dataSize,
+		Features:  1,
+		Coef:      []float64{trueW},
+		Intercept: trueB,
+		XMin:      -10.0,
+		XMax:      10.0,
+		Noise:     noise,
+		Outliers:  datasets.Outliers{Ratio: *outlierRatio, Shift: -35, Spread: 7, Leverage: true},
+	})
+	table, loaded, err := csvFlags.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if loaded {
+		if len(table.FeatureNames) != 1 {
+			log.Fatalf("单特征线性回归需要恰好 1 个特征列，实际为 %v，请用 -features 指定", table.FeatureNames)
+		}
+		if dataset, err = table.Regression(); err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		table = dataset.Table()
+	}
+	if err := csvFlags.Save(table); err != nil {
+		log.Fatal(err)
+	}
+	data := dataset.Points()
+	lr := *lrFlag   // 学习率
+	initialB := 0.0 // 初始化 b 为 0
+	initialW := 0.0 // 初始化 w 为 0
+
+	penalty, err := train.NewPenalty(*penaltyName, *alpha, *l1Ratio)
+	if err != nil {
+		log.Fatal(err)
+	}
+	penalty.PenalizeIntercept = *penalizeBias
+
+	lossFn, err := loss.New(*lossName, *lossParam)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 执行梯度下降，优化 w 和 b，每 50 轮打印一次损失
+	cfg := train.Config{
+		LR:        lr,
+		Epochs:    *numEpochs,
+		BatchSize: *batchSize,
+		Seed:      seed,
+		Optimizer: opt,
+		LogEvery:  50,
+		Penalty:   penalty,
+		Workers:   *workers,
+		Stop: train.StopRule{
+			LossTol:  *lossTol,
+			GradTol:  *gradTol,
+			ParamTol: *paramTol,
+			Patience: *patience,
+		},
+	}
+	cfg.Schedule, err = optim.NewSchedule(*scheduleName, lr, cfg.TotalSteps(len(data)), *warmupSteps)
+	if err != nil {
+		log.Fatal(err)
+	}
+	b, w, hist := linreg.GradientDescent(data, initialB, initialW, lossFn, cfg)
+
+	// 计算最终的损失和均方误差
+	cost := linreg.Cost(b, w, data, lossFn)
+	mse := linreg.Mse(b, w, data)
+
+	// 打印最终结果
+	fmt.Printf("Final loss(%s):%f, mse:%f, w:%f, b:%f\n", *lossName, cost, mse, w, b)
+	if !loaded {
+		fmt.Printf("True w:%f, b:%f\n", trueW, trueB)
+	}
+	fmt.Printf("Epochs:%d, gradient norm:%.3g\n", hist.Epochs(), hist.GradNorm[hist.Epochs()-1])
+	if hist.StopReason != "" {
+		fmt.Printf("Stopped early: %s\n", hist.StopReason)
+	}
+
+	// 在全部样本上计算回归指标
+	X, y := linreg.PointsToMatrix(data)
+	fmt.Print("\n", metrics.EvaluateRegression(y, linreg.Predict(b, []float64{w}, X), 1))
+
+	if *savePath != "" {
+		m := &model.Model{
+			Kind:      model.Linear,
+			Features:  table.FeatureNames,
+			Target:    table.TargetName,
+			Intercept: b,
+			Coef:      []float64{w},
+			Hyperparams: map[string]interface{}{
+				"lr": lr, "epochs": *numEpochs, "batch": *batchSize, "optimizer": *optimizerName,
+				"schedule": *scheduleName, "loss": *lossName, "loss_param": *lossParam,
+				"penalty": *penaltyName, "alpha": *alpha, "l1_ratio": *l1Ratio, "seed": seed,
+			},
+			Data: model.NewFingerprint(dataset.X, dataset.Y),
+		}
+		if err := model.Save(*savePath, m); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("模型已保存到 %s\n", *savePath)
+	}
+
+	// 与最小二乘最优解对比，判断是训练不足还是学习率有问题
+	if *compareMethod != "" {
+		cmp, err := linreg.Compare(b, []float64{w}, X, y, linreg.Method(*compareMethod))
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(cmp)
+		fmt.Println("Diagnosis:", cmp.Diagnose(hist))
+	}
+}
+
+// 生成数据所用的真实参数
+const (
+	trueW = 1.477
+	trueB = 0.089
+)
@@ -0,0 +1,145 @@
+// Package linregmulticmd 实现 ai linreg-multi 子命令：用梯度下降训练多特征线性回归
+package linregmulticmd
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"runtime"
+
+	"ai/datasets"
+	"ai/linreg"
+	"ai/loss"
+	"ai/optim"
+	"ai/rng"
+	"ai/train"
+)
+
+var (
+	fs            = flag.NewFlagSet("linreg-multi", flag.ExitOnError)
+	optimizerName = fs.String("optimizer", "sgd", fmt.Sprintf("参数更新规则，可选：%v", optim.Names()))
+	batchSize     = fs.Int("batch", 0, "每步使用的样本数，0 为全批量，1 为随机梯度下降")
+	numEpochs     = fs.Int("epochs", 1000, "遍历全部样本的轮数，全批量时即迭代次数")
+	seedFlag      = fs.Int64("seed", 1, rng.FlagUsage)
+	scheduleName  = fs.String("schedule", "constant", fmt.Sprintf("学习率调度，可选：%v", optim.ScheduleNames()))
+	warmupSteps   = fs.Int("warmup", 0, "线性预热的步数，0 表示不预热")
+	lossTol       = fs.Float64("loss-tol", 0, "相邻两轮损失变化小于该值时停止，0 表示不启用")
+	gradTol       = fs.Float64("grad-tol", 0, "梯度范数小于该值时停止，0 表示不启用")
+	paramTol      = fs.Float64("param-tol", 0, "参数变化量小于该值时停止，0 表示不启用")
+	patience      = fs.Int("patience", 0, "连续多少轮损失没有改善时停止，0 表示不启用")
+	penaltyName   = fs.String("penalty", "", "正则化：ridge, lasso, elasticnet，为空时不正则化")
+	alpha         = fs.Float64("alpha", 0.01, "正则化强度")
+	l1Ratio       = fs.Float64("l1-ratio", 0.5, "elasticnet 中 L1 部分所占比例")
+	penalizeBias  = fs.Bool("penalize-intercept", false, "是否也惩罚偏置（截距）")
+	useCD         = fs.Bool("cd", false, "训练后再用坐标下降求解同一个带正则化的目标函数，对比两者结果")
+	dataSize      = fs.Int("n", 50000, "合成数据的样本数")
+	numFeatures   = fs.Int("dims", 8, "合成数据的特征数")
+	lrFlag        = fs.Float64("lr", 0.01, "学习率")
+	compareMethod = fs.String("compare", "", fmt.Sprintf("训练后与最小二乘闭式解对比，可选：%v，为空时不对比", linreg.Methods()))
+	lossName      = fs.String("loss", "mse", fmt.Sprintf("损失函数，可选：%v", loss.Names()))
+	lossParam     = fs.Float64("loss-param", 0, "huber 的 delta、tukey 的 c 或 quantile 的分位数，0 表示默认值")
+	workers       = fs.Int("workers", runtime.NumCPU(), "并行计算损失和梯度的 goroutine 数，1 为单线程")
+)
+
+// Main 运行子命令，args 是子命令之后的命令行参数
+func Main(args []string) {
+	fs.Parse(args)
+	if *numEpochs < 1 {
+		log.Fatalf("-epochs 至少为 1，当前为 %d", *numEpochs)
+	}
+	seed := rng.Setup(*seedFlag)
+	opt, err := optim.New(*optimizerName)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 模拟多特征样本数据，实际使用时替换为真实数据
+	// 模拟 y = X·w + b + eps 形式的多特征样本：权重在 -3 到 3 之间随机取值，
+	// 每个特征在 -10 到 10 之间均匀采样，高斯噪声的标准差为 0.01
+	dataset := datasets.MakeRegression(rng.Source(seed), datasets.RegressionConfig{
+		Samples:   *dataSize,
+		Features:  *numFeatures,
+		CoefRange: 3.0,
+		Intercept: 0.089,
+		XMin:      -10.0,
+		XMax:      10.0,
+		Noise:     datasets.Gaussian{Sigma: 0.01},
+	})
+	X, y, trueB, trueW := dataset.X, dataset.Y, dataset.Intercept, dataset.Coef
+	lr := *lrFlag   // 学习率
+	initialB := 0.0 // 初始化 b 为 0
+
+	penalty, err := train.NewPenalty(*penaltyName, *alpha, *l1Ratio)
+	if err != nil {
+		log.Fatal(err)
+	}
+	penalty.PenalizeIntercept = *penalizeBias
+
+	lossFn, err := loss.New(*lossName, *lossParam)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 执行梯度下降，优化权重向量 w 和偏置 b（w 为 nil 表示全部从 0 开始），每 50 轮打印一次损失
+	cfg := train.Config{
+		LR:        lr,
+		Epochs:    *numEpochs,
+		BatchSize: *batchSize,
+		Seed:      seed,
+		Optimizer: opt,
+		LogEvery:  50,
+		Penalty:   penalty,
+		Workers:   *workers,
+		Stop: train.StopRule{
+			LossTol:  *lossTol,
+			GradTol:  *gradTol,
+			ParamTol: *paramTol,
+			Patience: *patience,
+		},
+	}
+	cfg.Schedule, err = optim.NewSchedule(*scheduleName, lr, cfg.TotalSteps(len(y)), *warmupSteps)
+	if err != nil {
+		log.Fatal(err)
+	}
+	b, w, hist := linreg.GradientDescentMulti(X, y, initialB, nil, lossFn, cfg)
+
+	// 计算最终的损失和均方误差
+	cost := linreg.CostMulti(b, w, X, y, lossFn)
+	mse := linreg.MseMulti(b, w, X, y)
+
+	// 打印最终结果，并与真实参数对比
+	fmt.Printf("Final loss(%s):%f, mse:%f, b:%f\n", *lossName, cost, mse, b)
+	fmt.Printf("Epochs:%d, gradient norm:%.3g\n", hist.Epochs(), hist.GradNorm[hist.Epochs()-1])
+	if hist.StopReason != "" {
+		fmt.Printf("Stopped early: %s\n", hist.StopReason)
+	}
+
+	// 与最小二乘最优解对比，判断是训练不足还是学习率有问题
+	if *compareMethod != "" {
+		cmp, err := linreg.Compare(b, w, X, y, linreg.Method(*compareMethod))
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(cmp)
+		fmt.Println("Diagnosis:", cmp.Diagnose(hist))
+	}
+
+	// 坐标下降能把 Lasso 中不重要的权重精确地压到 0，用来检验梯度下降的结果
+	var cdW []float64
+	if *useCD {
+		var cdB float64
+		var iters int
+		cdB, cdW, iters = linreg.CoordinateDescent(X, y, penalty, *numEpochs, 1e-10)
+		fmt.Printf("Coordinate descent: iterations:%d, b:%f, objective:%f\n", iters, cdB,
+			linreg.MseMulti(cdB, cdW, X, y)+penalty.Value(append([]float64{cdB}, cdW...)))
+	}
+
+	fmt.Printf("True b:%f\n", trueB)
+	for j := range w {
+		if cdW != nil {
+			fmt.Printf("w[%d]: fit=%f, cd=%f, true=%f\n", j, w[j], cdW[j], trueW[j])
+		} else {
+			fmt.Printf("w[%d]: fit=%f, true=%f\n", j, w[j], trueW[j])
+		}
+	}
+}
@@ -0,0 +1,572 @@
+// Package linregvizcmd 实现 ai linreg-viz 子命令：用 Ebiten 实时展示线性回归的梯度下降过程
+package linregvizcmd
+
+import (
+	"flag"
+	"fmt"
+	"image/color"
+	"math"
+	"os"
+	"time"
+
+	"ai/datasets"
+	"ai/features"
+	"ai/linreg"
+	"ai/loss"
+	"ai/optim"
+	"ai/rng"
+	"ai/scale"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/exp/rand"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"gonum.org/v1/gonum/mat"
+)
+
+const (
+	screenWidth  = 1500
+	screenHeight = 800
+	axisWidth    = 2.0
+	xAxisYPos    = screenHeight / 2.0
+	yAxisXPos    = screenWidth / 2.0
+	// 数学坐标系范围
+	xMin = -30.0
+	xMax = 30.0
+	yMin = -20.0
+	yMax = 20.0
+	// 控制更新间隔（秒）
+	updateInterval = 0.0001
+)
+
+// 颜色定义
+var (
+	axisColor     = color.RGBA{255, 255, 255, 255}
+	pointColor    = color.RGBA{255, 255, 255, 128}
+	trueLineColor = color.RGBA{0, 255, 0, 255}
+	fitLineColor  = color.RGBA{165, 120, 32, 255}
+	robustColor   = color.RGBA{255, 0, 255, 255}
+	gridColor     = color.RGBA{100, 100, 100, 60}
+	labelColor    = color.RGBA{255, 255, 255, 255}
+	progressColor = color.RGBA{0, 150, 255, 255}
+)
+
+// 全局状态
+var (
+	dataSize      int = 2000
+	data          [][]float64
+	w, b          float64
+	lr            = 0.0005
+	numIterations = 200000
+	step          = 0
+	ttfFont       font.Face
+	lastUpdate    time.Time // 记录上次更新时间
+	tw, tb        float64   = 1.72212862, 2.65145218
+	Sigma         float64   = 1.548564
+	opt           optim.Optimizer
+	fs            = flag.NewFlagSet("linreg-viz", flag.ExitOnError)
+	optimizerName = fs.String("optimizer", "sgd", fmt.Sprintf("参数更新规则，可选：%v", optim.Names()))
+	schedule      optim.Schedule
+	currentLR     = lr // 当前步实际使用的学习率
+	scheduleName  = fs.String("schedule", "constant", fmt.Sprintf("学习率调度，可选：%v", optim.ScheduleNames()))
+	warmupSteps   = fs.Int("warmup", 0, "线性预热的步数，0 表示不预热")
+	compareMethod = fs.String("compare", "", fmt.Sprintf("与最小二乘闭式解对比，可选：%v，为空时不对比", linreg.Methods()))
+	exactW        float64 // 最小二乘最优解，仅在 -compare 时计算
+	exactB        float64
+	exactLoss     float64
+	basisName     = fs.String("basis", "", "特征展开：poly, rbf, fourier，为空时拟合直线")
+	basisDegree   = fs.Int("degree", 3, "多项式的最高次数")
+	basisCount    = fs.Int("count", 10, "RBF 中心个数或傅里叶阶数")
+	expander      features.Expander // 仅在 -basis 时使用，此时拟合 y = b + basisW·φ(x)
+	phi           *mat.Dense
+	targets       []float64
+	basisW        []float64
+	lossName      = fs.String("loss", "", fmt.Sprintf("同时用该损失训练一个稳健模型并与均方误差拟合对比，可选：%v，为空时不对比", loss.Names()))
+	lossParam     = fs.Float64("loss-param", 0, "huber 的 delta、tukey 的 c 或 quantile 的分位数，0 表示默认值")
+	outlierRatio  = fs.Float64("outliers", 0, "替换为离群点的样本比例，取值 [0, 1)")
+	noiseName     = fs.String("noise", "gaussian", fmt.Sprintf("目标值上的噪声分布，可选：%v", datasets.NoiseNames()))
+	robustLoss    loss.Loss // 仅在 -loss 时使用，稳健模型的参数为 rw、rb（特征展开时为 rb、robustBasisW）
+	robustOpt     optim.Optimizer
+	rw, rb        float64
+	robustBasisW  []float64
+	seedFlag      = fs.Int64("seed", 1, rng.FlagUsage)
+	seed          int64
+	src           rand.Source // 数据生成使用的随机源，由 seed 决定
+	lrFlag        = fs.Float64("lr", lr, "学习率；特征缩放后可以用大得多的值，例如 -scale standard -lr 0.1")
+	dataSizeFlag  = fs.Int("n", dataSize, "样本数")
+	iterFlag      = fs.Int("iterations", numIterations, "梯度下降的迭代次数")
+	sigmaFlag     = fs.Float64("sigma", Sigma, "目标值上噪声的幅度（高斯噪声的标准差）")
+	scaleName     = fs.String("scale", "", fmt.Sprintf("特征缩放，可选：%v，为空时不缩放", scale.Names()))
+	// 训练始终在缩放后的设计矩阵 design（直线时为 x，特征展开时为 φ(x)）上进行，参数为 sb、sw
+	// （稳健模型为 srb、srw），每步之后用 scaler 换算回原始单位的 w、b、basisW 等，
+	// 因此图例、损失和与真实直线 tw、tb 的对比都保持在原始坐标下
+	scaler  scale.Scaler
+	design  *mat.Dense
+	sb, srb float64
+	sw, srw []float64
+)
+
+// initData 在真实直线 y = tw*x + tb 附近生成样本；离群点集中在画面右下角、远低于真实直线，
+// 会把均方误差拟合的直线往下拉
+func initData(numSamples int) {
+	noise, err := datasets.NewNoise(*noiseName, Sigma)
+	if err != nil {
+		panic(err)
+	}
+	data = datasets.MakeRegression(src, datasets.RegressionConfig{
+		Samples:   numSamples,
+		Features:  1,
+		Coef:      []float64{tw},
+		Intercept: tb,
+		XMin:      xMin,
+		XMax:      xMax,
+		Noise:     noise,
+		Outliers:  datasets.Outliers{Ratio: *outlierRatio, Shift: -60, Spread: 8, Leverage: true},
+	}).Points()
+}
+
+type Game struct{}
+
+// Update 控制更新节奏，每0.5秒执行一次梯度下降
+func (g *Game) Update() error {
+	// 首次运行初始化时间
+	if lastUpdate.IsZero() {
+		lastUpdate = time.Now()
+		return nil
+	}
+
+	// 检查是否达到更新间隔
+	now := time.Now()
+	if now.Sub(lastUpdate) >= time.Duration(updateInterval*float64(time.Second)) && step < numIterations {
+		currentLR = schedule.Rate(step)
+		sb, sw = linreg.StepGradientMulti(sb, sw, design, targets, currentLR, opt, nil)
+		// 稳健模型使用同样的学习率和优化器类型，只是换了损失函数
+		if robustLoss != nil {
+			srb, srw = linreg.StepGradientMulti(srb, srw, design, targets, currentLR, robustOpt, robustLoss)
+		}
+		unscaleParams()
+		step++
+		if step%2 == 0 {
+			fmt.Printf("Iteration:%d, loss:%f, lr:%.8f, %s\n", step, currentLoss(), currentLR, paramsLabel())
+			if robustLoss != nil {
+				fmt.Printf("  robust(%s): %s\n", *lossName, robustLabel())
+			}
+		}
+		lastUpdate = now
+	}
+	return nil
+}
+
+func (g *Game) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{0, 0, 0, 255})
+
+	// 绘制网格和坐标轴
+	drawGrid(screen)
+	drawAxis(screen)
+	drawAxisLabels(screen)
+
+	// 绘制样本点
+	drawPoints(screen, data)
+
+	// 绘制当前拟合直线（或特征展开后的拟合曲线）
+	if expander != nil {
+		drawCurve(screen, features.Curve(expander, b, basisW), fitLineColor)
+	} else {
+		drawLine(screen, w, b, fitLineColor)
+	}
+
+	// 绘制稳健损失的拟合结果
+	if robustLoss != nil && expander != nil {
+		drawCurve(screen, features.Curve(expander, rb, robustBasisW), robustColor)
+	} else if robustLoss != nil {
+		drawLine(screen, rw, rb, robustColor)
+	}
+
+	// 绘制真实直线
+	drawLine(screen, tw, tb, trueLineColor)
+
+	// 绘制图例
+	drawLegend(screen)
+
+	// 绘制当前参数和损失
+	drawStats(screen)
+
+	// 绘制进度条
+	drawProgressBar(screen)
+}
+
+func (g *Game) Layout(_, _ int) (int, int) {
+	return screenWidth, screenHeight
+}
+
+func drawGrid(screen *ebiten.Image) {
+	// 绘制垂直网格线
+	for x := xMin; x <= xMax; x += 1.0 {
+		xScreen := math.Round((x - xMin) / (xMax - xMin) * screenWidth)
+		ebitenutil.DrawLine(screen, xScreen, 0, xScreen, screenHeight, gridColor)
+	}
+
+	// 绘制水平网格线
+	for y := yMin; y <= yMax; y += 1.0 {
+		yScreen := math.Round((yMax - y) / (yMax - yMin) * screenHeight)
+		ebitenutil.DrawLine(screen, 0, yScreen, screenWidth, yScreen, gridColor)
+	}
+}
+
+func drawAxis(screen *ebiten.Image) {
+	// 绘制 x 轴
+	ebitenutil.DrawLine(screen, 0, xAxisYPos, screenWidth, xAxisYPos, axisColor)
+	// 绘制 y 轴
+	ebitenutil.DrawLine(screen, yAxisXPos, 0, yAxisXPos, screenHeight, axisColor)
+
+	// 绘制箭头
+	arrowSize := 10.0
+	// x 轴箭头
+	ebitenutil.DrawLine(screen, screenWidth-arrowSize, xAxisYPos-arrowSize/2, screenWidth, xAxisYPos, axisColor)
+	ebitenutil.DrawLine(screen, screenWidth-arrowSize, xAxisYPos+arrowSize/2, screenWidth, xAxisYPos, axisColor)
+	// y 轴箭头
+	ebitenutil.DrawLine(screen, yAxisXPos-arrowSize/2, arrowSize, yAxisXPos, 0, axisColor)
+	ebitenutil.DrawLine(screen, yAxisXPos+arrowSize/2, arrowSize, yAxisXPos, 0, axisColor)
+}
+
+func drawAxisLabels(screen *ebiten.Image) {
+	// 绘制 x 轴刻度
+	for x := xMin; x <= xMax; x += 2.0 {
+		xScreen := math.Round((x - xMin) / (xMax - xMin) * screenWidth)
+		ebitenutil.DrawLine(screen, xScreen, xAxisYPos-5, xScreen, xAxisYPos+5, axisColor)
+		if x != 0 { // 0 点已被 y 轴标签覆盖
+			label := fmt.Sprintf("%.0f", x)
+			if ttfFont != nil {
+				text.Draw(screen, label, ttfFont, int(xScreen)-5, int(xAxisYPos)+20, labelColor)
+			} else {
+				ebitenutil.DebugPrintAt(screen, label, int(xScreen)-5, int(xAxisYPos)+15)
+			}
+		}
+	}
+
+	// 绘制 y 轴刻度
+	for y := yMin; y <= yMax; y += 2.0 {
+		yScreen := math.Round((yMax - y) / (yMax - yMin) * screenHeight)
+		ebitenutil.DrawLine(screen, yAxisXPos-5, yScreen, yAxisXPos+5, yScreen, axisColor)
+		if y != 0 { // 0 点已被 x 轴标签覆盖
+			label := fmt.Sprintf("%.0f", y)
+			if ttfFont != nil {
+				text.Draw(screen, label, ttfFont, int(yAxisXPos)+10, int(yScreen)+5, labelColor)
+			} else {
+				ebitenutil.DebugPrintAt(screen, label, int(yAxisXPos)+10, int(yScreen)-5)
+			}
+		}
+	}
+
+	// 绘制轴标签
+	if ttfFont != nil {
+		text.Draw(screen, "x", ttfFont, screenWidth-15, int(xAxisYPos)-15, labelColor)
+		text.Draw(screen, "y", ttfFont, int(yAxisXPos)+15, 20, labelColor)
+	} else {
+		ebitenutil.DebugPrintAt(screen, "x", screenWidth-15, int(xAxisYPos)-15)
+		ebitenutil.DebugPrintAt(screen, "y", int(yAxisXPos)+15, 20)
+	}
+}
+
+func drawPoints(screen *ebiten.Image, points [][]float64) {
+	for _, p := range points {
+		x, y := p[0], p[1]
+		xScreen := (x - xMin) / (xMax - xMin) * screenWidth
+		yScreen := (yMax - y) / (yMax - yMin) * screenHeight
+		ebitenutil.DrawCircle(screen, xScreen, yScreen, 2, pointColor)
+	}
+}
+
+func drawLine(screen *ebiten.Image, w, b float64, c color.Color) {
+	x1, y1 := xMin, w*xMin+b
+	x2, y2 := xMax, w*xMax+b
+
+	x1Screen := (x1 - xMin) / (xMax - xMin) * screenWidth
+	y1Screen := (yMax - y1) / (yMax - yMin) * screenHeight
+	x2Screen := (x2 - xMin) / (xMax - xMin) * screenWidth
+	y2Screen := (yMax - y2) / (yMax - yMin) * screenHeight
+
+	ebitenutil.DrawLine(screen, x1Screen, y1Screen, x2Screen, y2Screen, c)
+}
+
+// drawCurve 把曲线 y = f(x) 切成许多小段，逐段用直线画出
+func drawCurve(screen *ebiten.Image, f func(x float64) float64, c color.Color) {
+	const segments = 300
+	dx := (xMax - xMin) / segments
+	for i := 0; i < segments; i++ {
+		x1, x2 := xMin+float64(i)*dx, xMin+float64(i+1)*dx
+		y1, y2 := f(x1), f(x2)
+
+		x1Screen := (x1 - xMin) / (xMax - xMin) * screenWidth
+		y1Screen := (yMax - y1) / (yMax - yMin) * screenHeight
+		x2Screen := (x2 - xMin) / (xMax - xMin) * screenWidth
+		y2Screen := (yMax - y2) / (yMax - yMin) * screenHeight
+
+		ebitenutil.DrawLine(screen, x1Screen, y1Screen, x2Screen, y2Screen, c)
+	}
+}
+
+// unscaleParams 把缩放后特征上的参数换算成原始单位，供绘图、图例和损失计算使用
+func unscaleParams() {
+	var origW []float64
+	b, origW = scaler.UnscaleCoef(sb, sw)
+	if expander != nil {
+		basisW = origW
+	} else {
+		w = origW[0]
+	}
+	if robustLoss == nil {
+		return
+	}
+	rb, origW = scaler.UnscaleCoef(srb, srw)
+	if expander != nil {
+		robustBasisW = origW
+	} else {
+		rw = origW[0]
+	}
+}
+
+// currentLoss 返回当前参数下的均方误差
+func currentLoss() float64 {
+	if expander != nil {
+		return linreg.MseMulti(b, basisW, phi, targets)
+	}
+	return linreg.Mse(b, w, data)
+}
+
+// paramsLabel 描述当前参数
+func paramsLabel() string {
+	if expander != nil {
+		return fmt.Sprintf("b=%.6f, w=%.4f", b, basisW)
+	}
+	return fmt.Sprintf("w=%.8f, b=%.8f", w, b)
+}
+
+// robustLabel 描述稳健模型的当前参数和损失
+func robustLabel() string {
+	if expander != nil {
+		return fmt.Sprintf("loss=%.6f, b=%.6f, w=%.4f", linreg.CostMulti(rb, robustBasisW, phi, targets, robustLoss), rb, robustBasisW)
+	}
+	return fmt.Sprintf("loss=%.6f, w=%.8f, b=%.8f", linreg.Cost(rb, rw, data, robustLoss), rw, rb)
+}
+
+// fitLabel 拟合线的图例文字
+func fitLabel() string {
+	if expander != nil {
+		return fmt.Sprintf("Fit Curve (%s, %d features)", *basisName, expander.NumOutputs())
+	}
+	return fmt.Sprintf("Fit Line (y=%.8fx+%.8f)", w, b)
+}
+
+func drawLegend(screen *ebiten.Image) {
+	legendX := 20
+	legendY := 20
+	legendSpacing := 20
+
+	// 绘制图例标题
+	if ttfFont != nil {
+		text.Draw(screen, "Legend:", ttfFont, legendX, legendY, labelColor)
+	} else {
+		ebitenutil.DebugPrintAt(screen, "Legend:", legendX, legendY)
+	}
+
+	// 绘制真实直线图例
+	ebitenutil.DrawLine(screen, float64(legendX), float64(legendY+legendSpacing),
+		float64(legendX+30), float64(legendY+legendSpacing), trueLineColor)
+	if ttfFont != nil {
+		text.Draw(screen, fmt.Sprintf("True Line (y=%.8fx+%.8f)", tw, tb), ttfFont, legendX+40, legendY+legendSpacing+5, labelColor)
+	} else {
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("True Line (y=%.8fx+%.8f)", tw, tb), legendX+40, legendY+legendSpacing-5)
+	}
+
+	// 绘制拟合直线图例
+	ebitenutil.DrawLine(screen, float64(legendX), float64(legendY+legendSpacing*2),
+		float64(legendX+30), float64(legendY+legendSpacing*2), fitLineColor)
+	if ttfFont != nil {
+		text.Draw(screen, fitLabel(), ttfFont, legendX+40, legendY+legendSpacing*2+5, labelColor)
+	} else {
+		ebitenutil.DebugPrintAt(screen, fitLabel(), legendX+40, legendY+legendSpacing*2-5)
+	}
+
+	// 绘制稳健拟合图例
+	if robustLoss == nil {
+		return
+	}
+	robustText := fmt.Sprintf("Robust Fit (%s)", *lossName)
+	if expander == nil {
+		robustText = fmt.Sprintf("Robust Fit (%s, y=%.8fx+%.8f)", *lossName, rw, rb)
+	}
+	ebitenutil.DrawLine(screen, float64(legendX), float64(legendY+legendSpacing*3),
+		float64(legendX+30), float64(legendY+legendSpacing*3), robustColor)
+	if ttfFont != nil {
+		text.Draw(screen, robustText, ttfFont, legendX+40, legendY+legendSpacing*3+5, labelColor)
+	} else {
+		ebitenutil.DebugPrintAt(screen, robustText, legendX+40, legendY+legendSpacing*3-5)
+	}
+}
+
+func drawStats(screen *ebiten.Image) {
+	statsX := 20
+	statsY := screenHeight - 160
+	statsSpacing := 20
+
+	loss := currentLoss()
+	progress := float64(step) / float64(numIterations) * 100
+
+	if ttfFont != nil {
+		text.Draw(screen, "Training Progress:", ttfFont, statsX, statsY, labelColor)
+		text.Draw(screen, fmt.Sprintf("Iteration: %d/%d (%.1f%%)", step, numIterations, progress), ttfFont, statsX, statsY+statsSpacing, labelColor)
+		text.Draw(screen, fmt.Sprintf("Loss: %.6f", loss), ttfFont, statsX, statsY+statsSpacing*2, labelColor)
+		text.Draw(screen, "Parameters: "+paramsLabel(), ttfFont, statsX, statsY+statsSpacing*3, labelColor)
+		text.Draw(screen, fmt.Sprintf("Learning Rate: %.8f (%s)", currentLR, *scheduleName), ttfFont, statsX, statsY+statsSpacing*4, labelColor)
+		if *compareMethod != "" {
+			text.Draw(screen, exactStats(loss), ttfFont, statsX, statsY+statsSpacing*5, labelColor)
+		}
+	} else {
+		ebitenutil.DebugPrintAt(screen, "Training Progress:", statsX, statsY)
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Iteration: %d/%d (%.1f%%)", step, numIterations, progress), statsX, statsY+statsSpacing)
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Loss: %.6f", loss), statsX, statsY+statsSpacing*2)
+		ebitenutil.DebugPrintAt(screen, "Parameters: "+paramsLabel(), statsX, statsY+statsSpacing*3)
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Learning Rate: %.8f (%s)", currentLR, *scheduleName), statsX, statsY+statsSpacing*4)
+		if *compareMethod != "" {
+			ebitenutil.DebugPrintAt(screen, exactStats(loss), statsX, statsY+statsSpacing*5)
+		}
+	}
+}
+
+// exactStats 描述当前拟合结果与最小二乘最优解的差距
+func exactStats(loss float64) string {
+	if expander != nil {
+		return fmt.Sprintf("Exact (%s): loss=%.6f, loss gap=%.3g", *compareMethod, exactLoss, loss-exactLoss)
+	}
+	return fmt.Sprintf("Exact (%s): w=%.8f, b=%.8f, loss gap=%.3g", *compareMethod, exactW, exactB, loss-exactLoss)
+}
+
+func drawProgressBar(screen *ebiten.Image) {
+	barX := 20
+	barY := screenHeight - 30
+	barWidth := screenWidth - 40
+	barHeight := 15
+
+	// 绘制进度条背景
+	ebitenutil.DrawRect(screen, float64(barX), float64(barY), float64(barWidth), float64(barHeight), color.RGBA{50, 50, 50, 255})
+
+	// 计算进度条长度
+	progress := float64(step) / float64(numIterations)
+	progressWidth := float64(barWidth) * progress
+
+	// 绘制进度条
+	ebitenutil.DrawRect(screen, float64(barX), float64(barY), progressWidth, float64(barHeight), progressColor)
+
+	// 绘制进度文本
+	progressText := fmt.Sprintf("Training: %.1f%%", progress*100)
+	if ttfFont != nil {
+		text.Draw(screen, progressText, ttfFont, barX+10, barY+12, labelColor)
+	} else {
+		ebitenutil.DebugPrintAt(screen, progressText, barX+10, barY+5)
+	}
+}
+
+func loadFont() {
+	// 尝试加载系统字体
+	// 注意：实际使用时可能需要提供具体的字体文件路径
+	fontBytes, err := os.ReadFile("arial.ttf")
+	if err != nil {
+		fmt.Println("Failed to load font, using default:", err)
+		return
+	}
+
+	f, err := opentype.Parse(fontBytes)
+	if err != nil {
+		fmt.Println("Failed to parse font:", err)
+		return
+	}
+
+	ttfFont, err = opentype.NewFace(f, &opentype.FaceOptions{
+		Size:    12,
+		DPI:     72,
+		Hinting: font.HintingFull,
+	})
+	if err != nil {
+		fmt.Println("Failed to create font face:", err)
+	}
+}
+
+// Main 运行子命令，args 是子命令之后的命令行参数
+func Main(args []string) {
+	fs.Parse(args)
+	dataSize, numIterations, Sigma = *dataSizeFlag, *iterFlag, *sigmaFlag
+	seed = rng.Setup(*seedFlag)
+	src = rng.Source(seed)
+	var err error
+	if opt, err = optim.New(*optimizerName); err != nil {
+		panic(err)
+	}
+	if schedule, err = optim.NewSchedule(*scheduleName, *lrFlag, numIterations, *warmupSteps); err != nil {
+		panic(err)
+	}
+
+	// 设置最大帧率，避免CPU占用过高
+	ebiten.SetMaxTPS(30) // 每秒最多30帧，足够流畅显示
+
+	// 尝试加载字体（可选）
+	loadFont()
+
+	// 初始化数据、参数
+	initData(dataSize)
+	var X *mat.Dense
+	X, targets = linreg.PointsToMatrix(data)
+	design = X
+	if *basisName != "" {
+		if expander, err = features.New(*basisName, *basisDegree, *basisCount, xMin, xMax); err != nil {
+			panic(err)
+		}
+		phi = features.Transform(expander, X)
+		basisW = make([]float64, expander.NumOutputs())
+		design = phi
+	}
+	if scaler, err = scale.New(*scaleName); err != nil {
+		panic(err)
+	}
+	design = scale.FitTransform(scaler, design)
+	_, numFeatures := design.Dims()
+	sw = make([]float64, numFeatures)
+	if *compareMethod != "" && expander != nil {
+		var exactBasisW []float64
+		if exactB, exactBasisW, err = linreg.SolveMulti(phi, targets, linreg.Method(*compareMethod)); err != nil {
+			panic(err)
+		}
+		exactLoss = linreg.MseMulti(exactB, exactBasisW, phi, targets)
+		fmt.Printf("Exact least squares (%s): b:%f, w:%.4f, loss:%f\n", *compareMethod, exactB, exactBasisW, exactLoss)
+	} else if *compareMethod != "" {
+		if exactB, exactW, err = linreg.Solve(data, linreg.Method(*compareMethod)); err != nil {
+			panic(err)
+		}
+		exactLoss = linreg.Mse(exactB, exactW, data)
+		fmt.Printf("Exact least squares (%s): w:%f, b:%f, loss:%f\n", *compareMethod, exactW, exactB, exactLoss)
+	}
+	if *lossName != "" {
+		if robustLoss, err = loss.New(*lossName, *lossParam); err != nil {
+			panic(err)
+		}
+		if robustOpt, err = optim.New(*optimizerName); err != nil {
+			panic(err)
+		}
+		if expander != nil {
+			robustBasisW = make([]float64, expander.NumOutputs())
+		}
+		srw = make([]float64, numFeatures)
+	}
+	// 从缩放后的零参数出发，换算回原始单位后的初始直线与不缩放时不同（截距不为 0）
+	unscaleParams()
+	lastUpdate = time.Now()
+
+	// 启动 Ebiten 可视化
+	ebiten.SetWindowSize(screenWidth, screenHeight)
+	ebiten.SetWindowTitle(fmt.Sprintf("Linear Regression Visualization (seed=%d)", seed))
+	if err := ebiten.RunGame(&Game{}); err != nil {
+		panic(err)
+	}
+}
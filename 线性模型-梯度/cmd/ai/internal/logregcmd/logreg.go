@@ -0,0 +1,742 @@
+// Package logregcmd 实现 ai logreg 子命令：用梯度下降训练逻辑回归，类别多于 2 个时训练 softmax 回归，
+// 两个特征时画出分类边界
+package logregcmd
+
+import (
+    "flag"
+    "fmt"
+    "image/color"
+    "log"
+    "math"
+    "runtime"
+    "time"
+
+    "ai/calibration"
+    "ai/cv"
+    "ai/datasets"
+    "ai/logreg"
+    "ai/metrics"
+    "ai/model"
+    "ai/optim"
+    "ai/rng"
+    "ai/scale"
+    "ai/softmax"
+    "ai/train"
+
+    "gonum.org/v1/gonum/floats"
+    "gonum.org/v1/gonum/mat"
+    "gonum.org/v1/plot"
+    "gonum.org/v1/plot/plotter"
+    "gonum.org/v1/plot/vg"
+)
+
+// 每个类别的颜色，二分类时 1 用红色、0 用蓝色
+var classColors = []color.RGBA{
+    {R: 0, G: 0, B: 255, A: 255},
+    {R: 255, G: 0, B: 0, A: 255},
+    {R: 255, G: 165, B: 0, A: 255},
+    {R: 0, G: 128, B: 0, A: 255},
+    {R: 0, G: 160, B: 160, A: 255},
+    {R: 139, G: 69, B: 19, A: 255},
+    {R: 255, G: 0, B: 255, A: 255},
+    {R: 128, G: 128, B: 0, A: 255},
+}
+
+func classColor(c int) color.RGBA {
+    return classColors[c%len(classColors)]
+}
+
+// boundaryColor 二分类的边界用绿色，多分类时类别 i 与 j 之间的边界取两者颜色的平均
+func boundaryColor(i, j, k int) color.RGBA {
+    if k == 2 {
+        return color.RGBA{R: 0, G: 255, B: 0, A: 255}
+    }
+    ci, cj := classColor(i), classColor(j)
+    return color.RGBA{R: uint8((int(ci.R) + int(cj.R)) / 2), G: uint8((int(ci.G) + int(cj.G)) / 2), B: uint8((int(ci.B) + int(cj.B)) / 2), A: 255}
+}
+
+// 绘制图像，包括原始数据（按类别着色）、真实分界线和训练后模型的分类边界；
+// k 个类别时画出每一对类别之间的边界，共 k(k-1)/2 条；二分类时只有一条，
+// 画的是概率等于 threshold 的位置，即 b + w·x = logit(threshold)
+func plotData(xData, yData []float64, labels []int, k int,
+    trueSlopes, trueIntercepts []float64, boundaries []softmax.Boundary, threshold float64, summary string) {
+    p := plot.New()
+ 
+    p.Title.Text = "Data Distribution and Fitted Line (" + summary + ")"
+    if k > 2 {
+        p.Title.Text = "Data Distribution and Decision Boundaries (" + summary + ")"
+    }
+    p.X.Label.Text = "X"
+    p.Y.Label.Text = "Y"
+
+    // 直线按数据的 x 范围绘制
+    x1, x2 := floats.Min(xData), floats.Max(xData)
+
+    // 绘制真实分界线，从文件读取的数据没有真实直线（trueSlopes 为 nil）
+    for j, slope := range trueSlopes {
+        trueLineData := make(plotter.XYs, 2)
+        trueLineData[0] = plotter.XY{X: x1, Y: slope*x1 + trueIntercepts[j]}
+        trueLineData[1] = plotter.XY{X: x2, Y: slope*x2 + trueIntercepts[j]}
+        trueLine, err := plotter.NewLine(trueLineData)
+        if err != nil {
+            panic(err)
+        }
+        trueLine.Color = color.RGBA{R: 0, G: 0, B: 0, A: 255}
+        trueLine.Width = vg.Points(2)
+        trueLine.Dashes = []vg.Length{vg.Points(5), vg.Points(5)}
+        p.Add(trueLine)
+        p.Legend.Add(fmt.Sprintf("True Line (y=%gx+%g)", slope, trueIntercepts[j]), trueLine)
+    }
+
+    // 绘制数据点，每个类别一种颜色；类别从大到小加入图例，二分类时依次是直线上方（1）和下方（0）
+    for c := k - 1; c >= 0; c-- {
+        pts := make(plotter.XYs, 0)
+        for i := range xData {
+            if labels[i] == c {
+                pts = append(pts, plotter.XY{X: xData[i], Y: yData[i]})
+            }
+        }
+        scatter, err := plotter.NewScatter(pts)
+        if err != nil {
+            panic(err)
+        }
+        scatter.Color = classColor(c)
+        scatter.Radius = vg.Points(3)
+        p.Add(scatter)
+        name := fmt.Sprintf("Class %d", c)
+        if k == 2 {
+            name = [...]string{"Below Line (0)", "Above Line (1)"}[c]
+        }
+        p.Legend.Add(name, scatter)
+    }
+
+    // 类别 I 与 J 的分类边界是 B + W0*x + W1*y = 0 → y = (-B - W0*x)/W1 （W1≠0时），
+    // 二分类时 B、W 就是逻辑回归的 b、w，阈值不是 0.5 时把 B 减去 logit(threshold)；
+    // 取 x 范围端点计算对应的 y 绘制直线
+    for _, bd := range boundaries {
+        if bd.W[1] == 0 {
+            continue
+        }
+        if k == 2 {
+            // 阈值不在 (0, 1) 内时所有点都判为同一类，没有边界可画
+            if threshold <= 0 || threshold >= 1 {
+                continue
+            }
+            bd.B -= logreg.Logit(threshold)
+        }
+        fitLineData := make(plotter.XYs, 2)
+        fitLineData[0] = plotter.XY{X: x1, Y: (-bd.B - bd.W[0]*x1) / bd.W[1]}
+        fitLineData[1] = plotter.XY{X: x2, Y: (-bd.B - bd.W[0]*x2) / bd.W[1]}
+        fitLine, err := plotter.NewLine(fitLineData)
+        if err != nil {
+            panic(err)
+        }
+        fitLine.Color = boundaryColor(bd.I, bd.J, k)
+        fitLine.Width = vg.Points(2)
+        p.Add(fitLine)
+        if k == 2 && threshold != 0.5 {
+            p.Legend.Add(fmt.Sprintf("Fitted Line (p=%.3g)", threshold), fitLine)
+        } else if k == 2 {
+            p.Legend.Add("Fitted Line", fitLine)
+        } else {
+            p.Legend.Add(fmt.Sprintf("Boundary %d|%d", bd.I, bd.J), fitLine)
+        }
+    }
+
+    // 几乎竖直的边界会把 y 轴拉得很长，只显示数据所在的范围
+    y1, y2 := floats.Min(yData), floats.Max(yData)
+    margin := (y2 - y1) * 0.05
+    p.Y.Min, p.Y.Max = y1-margin, y2+margin
+
+    if err := p.Save(10*vg.Inch, 8*vg.Inch, "result_plot.png"); err != nil {
+        panic(err)
+    }
+    fmt.Println("图像已保存为 result_plot.png")
+}
+
+var (
+    fs            = flag.NewFlagSet("logreg", flag.ExitOnError)
+    optimizerName = fs.String("optimizer", "sgd", fmt.Sprintf("参数更新规则，可选：%v", optim.Names()))
+    scheduleName  = fs.String("schedule", "constant", fmt.Sprintf("学习率调度，可选：%v", optim.ScheduleNames()))
+    warmupSteps   = fs.Int("warmup", 0, "线性预热的步数，0 表示不预热")
+    lossTol       = fs.Float64("loss-tol", 0, "相邻两轮损失变化小于该值时停止，0 表示不启用")
+    gradTol       = fs.Float64("grad-tol", 0, "梯度范数小于该值时停止，0 表示不启用")
+    paramTol      = fs.Float64("param-tol", 0, "参数变化量小于该值时停止，0 表示不启用")
+    patience      = fs.Int("patience", 0, "连续多少轮损失没有改善时停止，0 表示不启用")
+    penaltyName   = fs.String("penalty", "", "正则化：ridge, lasso, elasticnet，为空时不正则化")
+    alpha         = fs.Float64("alpha", 0.01, "正则化强度")
+    l1Ratio       = fs.Float64("l1-ratio", 0.5, "elasticnet 中 L1 部分所占比例")
+    penalizeBias  = fs.Bool("penalize-intercept", false, "是否也惩罚偏置（截距）")
+    workers       = fs.Int("workers", runtime.NumCPU(), "并行计算损失和梯度的 goroutine 数，1 为单线程")
+    seedFlag      = fs.Int64("seed", 1, rng.FlagUsage)
+    flipRatio     = fs.Float64("flip", 0, "随机翻转标签的样本比例，模拟标注噪声")
+    csvFlags      = datasets.RegisterCSVFlags(fs, "label")
+    cvMethod      = fs.String("cv", "stratified", fmt.Sprintf("交叉验证方式，可选：%v", cv.Methods()))
+    numFolds      = fs.Int("folds", 0, "交叉验证的折数，<2 时不做交叉验证；每折都要完整训练一次")
+    scaleName     = fs.String("scale", "", fmt.Sprintf("特征缩放，可选：%v，为空时不缩放；缩放后正则化作用在缩放后的权重上", scale.Names()))
+    lrFlag        = fs.Float64("lr", 0.006, "学习率；特征缩放后可以用更大的值，例如 -scale standard -lr 0.5")
+    iterFlag      = fs.Int("iterations", 200000, "最大迭代次数；特征缩放后通常几千次即可收敛")
+    dataSize      = fs.Int("n", 2000, "合成数据的样本数")
+    noiseMax      = fs.Float64("noise-max", 3.554646, "噪声幅度，y 在真实直线上加 [-noise-max, 2*noise-max) 的均匀噪声；多分类时加 [-noise-max/2, noise-max/2) 的均匀噪声")
+    numClasses    = fs.Int("classes", 2, "合成数据的类别数，大于 2 时用 classes-1 条平行直线划分类别，并训练 softmax 回归")
+    savePath      = fs.String("save", "", "把训练好的模型保存到该 JSON 文件，可用 ai predict 加载")
+    solverName    = fs.String("solver", "gd", fmt.Sprintf("二分类的求解方法：gd（梯度下降），或 %v，后两者只支持 ridge 正则化，迭代次数上限同 -iterations", logreg.Solvers()))
+    solverTol     = fs.Float64("solver-tol", 1e-8, "newton 和 lbfgs 在梯度范数小于该值时停止")
+    compare       = fs.Bool("compare-solvers", false, "训练后再用其余求解方法求解同一个目标函数，对比迭代次数、损失、梯度范数和用时")
+    classWeight   = fs.String("class-weight", "", fmt.Sprintf("类别权重，可选：%v，为空时每个类别权重相同；少数类的样本权重更大", train.ClassWeightNames()))
+    weightCol     = fs.String("weight-col", "", "用作样本权重的特征列（列名或列号），该列不参与训练；与 -class-weight 同时使用时两者相乘")
+    thresholdName = fs.String("threshold", "", fmt.Sprintf("二分类在训练数据上选择判为正类的概率阈值，可选：%v，为空时使用 0.5", metrics.ThresholdCriteria()))
+    costFlag      = fs.String("cost-matrix", "0,1,1,0", "-threshold cost 使用的代价，按 TN,FP,FN,TP 的顺序给出每个样本的代价")
+    calibName     = fs.String("calibrate", "", fmt.Sprintf("二分类的概率校准方法，可选：%v，为空时不校准；校准器在交叉验证的折外得分上拟合", calibration.Names()))
+    calibFolds    = fs.Int("calib-folds", 5, "拟合校准器时计算折外得分所用的折数，划分方式同 -cv")
+    calibBins     = fs.Int("calib-bins", 10, "可靠性图把 [0, 1] 等分成的区间数")
+)
+
+// fitLogistic 在缩放后的特征上训练逻辑回归，初始参数和返回的参数 b, w 都是原始单位，
+// 同时返回拟合好的缩放器；缩放器只用训练数据拟合，交叉验证时每折各自拟合一次
+func fitLogistic(X *mat.Dense, labels []int, weights []float64, b float64, w []float64, cfg train.Config) (float64, []float64, train.History, scale.Scaler) {
+    scaler, err := scale.New(*scaleName)
+    if err != nil {
+        log.Fatal(err)
+    }
+    Xs := scale.FitTransform(scaler, X)
+
+    sb, sw := scaler.ScaleCoef(b, w)
+    sb, sw, hist := logreg.GradientDescent(Xs, labels, weights, sb, sw, cfg)
+    b, w = scaler.UnscaleCoef(sb, sw)
+    return b, w, hist, scaler
+}
+
+// solveLogistic 与 fitLogistic 相同，但用 newton 或 lbfgs 从零开始求解，最多迭代 cfg.Epochs 次
+func solveLogistic(X *mat.Dense, labels []int, weights []float64, solver logreg.Solver, cfg train.Config) (float64, []float64, logreg.SolveResult, scale.Scaler) {
+    scaler, err := scale.New(*scaleName)
+    if err != nil {
+        log.Fatal(err)
+    }
+    Xs := scale.FitTransform(scaler, X)
+
+    res, err := logreg.Solve(Xs, labels, weights, solver, cfg.Penalty, cfg.Epochs, *solverTol)
+    if err != nil {
+        log.Fatal(err)
+    }
+    b, w := scaler.UnscaleCoef(res.B, res.W)
+    return b, w, res, scaler
+}
+
+// foldConfig 返回交叉验证中一折使用的训练设置：不打印损失，并换上新建的优化器。
+// 优化器带有状态（动量、累积梯度等），与其他折或最终的训练共用时会把状态带过去，改变训练出的模型
+func foldConfig(cfg train.Config) train.Config {
+    opt, err := optim.New(*optimizerName)
+    if err != nil {
+        log.Fatal(err)
+    }
+    cfg.Optimizer, cfg.LogEvery = opt, 0
+    return cfg
+}
+
+// fitBinary 按 -solver 用梯度下降或 newton、lbfgs 训练逻辑回归，只返回原始单位的参数
+func fitBinary(X *mat.Dense, labels []int, weights []float64, b float64, w []float64, cfg train.Config) (float64, []float64) {
+    if *solverName == "gd" {
+        b, w, _, _ = fitLogistic(X, labels, weights, b, w, cfg)
+    } else {
+        b, w, _, _ = solveLogistic(X, labels, weights, logreg.Solver(*solverName), cfg)
+    }
+    return b, w
+}
+
+// solverRow 求解方法对比表中的一行
+type solverRow struct {
+    name       string
+    iterations int
+    loss       float64
+    gradNorm   float64
+    runtime    time.Duration
+    status     string
+}
+
+// gdRow 把梯度下降的训练记录转换成对比表中的一行
+func gdRow(hist train.History, runtime time.Duration) solverRow {
+    last := hist.Epochs() - 1
+    status := hist.StopReason
+    if status == "" {
+        status = "跑满全部迭代"
+    }
+    return solverRow{"gd", hist.Epochs(), hist.Loss[last], hist.GradNorm[last], runtime, status}
+}
+
+func resultRow(res logreg.SolveResult) solverRow {
+    return solverRow{string(res.Solver), res.Iterations, res.Loss, res.GradNorm, res.Runtime, res.Status}
+}
+
+// compareSolvers 用除 done 之外的全部方法在同样缩放后的特征上求解同一个目标函数，
+// 与已经完成的 done 一起打印迭代次数、损失、梯度范数和用时
+func compareSolvers(X *mat.Dense, labels []int, weights []float64, b float64, w []float64, cfg train.Config, done solverRow) {
+    rows := []solverRow{done}
+    if done.name != "gd" {
+        // 优化器带有状态，重新创建一个
+        opt, err := optim.New(*optimizerName)
+        if err != nil {
+            log.Fatal(err)
+        }
+        gdCfg := cfg
+        gdCfg.Optimizer, gdCfg.LogEvery = opt, 0
+        start := time.Now()
+        _, _, hist, _ := fitLogistic(X, labels, weights, b, w, gdCfg)
+        rows = append(rows, gdRow(hist, time.Since(start)))
+    }
+    for _, solver := range logreg.Solvers() {
+        if string(solver) != done.name {
+            _, _, res, _ := solveLogistic(X, labels, weights, solver, cfg)
+            rows = append(rows, resultRow(res))
+        }
+    }
+
+    fmt.Printf("\n%-8s %12s %14s %14s %12s  %s\n", "solver", "iterations", "loss", "grad norm", "time", "status")
+    for _, r := range rows {
+        fmt.Printf("%-8s %12d %14.8g %14.6g %12v  %s\n", r.name, r.iterations, r.loss, r.gradNorm, r.runtime.Round(time.Microsecond), r.status)
+    }
+}
+
+// fitSoftmax 与 fitLogistic 相同，在缩放后的特征上从零开始训练 softmax 回归，
+// 返回原始单位的偏置 b 和 k×d 权重矩阵 W，每个类别的参数分别换算
+func fitSoftmax(X *mat.Dense, labels []int, weights []float64, k int, cfg train.Config) ([]float64, *mat.Dense, train.History, scale.Scaler) {
+    scaler, err := scale.New(*scaleName)
+    if err != nil {
+        log.Fatal(err)
+    }
+    Xs := scale.FitTransform(scaler, X)
+
+    sb, sW, hist := softmax.GradientDescent(Xs, labels, weights, k, nil, nil, cfg)
+    _, d := X.Dims()
+    b, W := make([]float64, k), mat.NewDense(k, d, nil)
+    for c := 0; c < k; c++ {
+        var w []float64
+        b[c], w = scaler.UnscaleCoef(sb[c], sW.RawRowView(c))
+        W.SetRow(c, w)
+    }
+    return b, W, hist, scaler
+}
+
+// sampleWeights 由 -class-weight 按 labels 计算类别权重，再乘以 -weight-col 给出的 base，
+// 都没有指定时返回 nil；交叉验证时每折只用训练折的标签计算
+func sampleWeights(labels []int, base []float64, k int) []float64 {
+    classWeights, err := train.ClassWeights(*classWeight, labels, k)
+    if err != nil {
+        log.Fatal(err)
+    }
+    return train.SampleWeights(labels, classWeights, base)
+}
+
+// subsetWeights 取出 idx 对应的样本权重，weights 为 nil 时仍返回 nil
+func subsetWeights(weights []float64, idx []int) []float64 {
+    if weights == nil {
+        return nil
+    }
+    return cv.Floats(weights, idx)
+}
+
+// tuneThreshold 按 -threshold 在 prob 上选择判为正类的概率阈值，未指定时使用 0.5（Criterion 为空）
+func tuneThreshold(labels []int, prob []float64) metrics.Threshold {
+    if *thresholdName == "" {
+        return metrics.Threshold{Threshold: 0.5}
+    }
+    costs, err := metrics.ParseCostMatrix(*costFlag)
+    if err != nil {
+        log.Fatal(err)
+    }
+    t, err := metrics.TuneThreshold(labels, prob, metrics.ThresholdCriterion(*thresholdName), costs)
+    if err != nil {
+        log.Fatal(err)
+    }
+    return t
+}
+
+// calibrateLogistic 用 -calib-folds 折交叉验证得到每个样本的折外决策值，即来自没有用它训练的模型的得分，
+// 在其上拟合 -calibrate 指定的校准器并包装全部数据上训练的模型 (b, w)；
+// 同时打印折外概率校准前后的 Brier 分数和可靠性曲线，并画出可靠性图。
+// 校准后的概率也按折交叉拟合校准器（calibration.CrossCalibrate），不会因为校准器见过这些样本而显得更准
+func calibrateLogistic(X *mat.Dense, yTrue []int, baseWeights []float64, b0 float64, w0 []float64,
+    b float64, w []float64, cfg train.Config, seed int64) *calibration.Classifier {
+    splits, err := cv.Splits(*cvMethod, rng.New(seed), len(yTrue), yTrue, *calibFolds)
+    if err != nil {
+        log.Fatal(err)
+    }
+    scores := make([]float64, len(yTrue))
+    for _, s := range splits {
+        trainX, trainY := cv.Rows(X, s.Train), cv.Ints(yTrue, s.Train)
+        fb, fw := fitBinary(trainX, trainY, sampleWeights(trainY, subsetWeights(baseWeights, s.Train), 2), b0, w0, foldConfig(cfg))
+        for k, v := range logreg.Decision(fb, fw, cv.Rows(X, s.Test)) {
+            scores[s.Test[k]] = v
+        }
+    }
+
+    raw := make([]float64, len(scores))
+    for i, v := range scores {
+        raw[i] = logreg.Sigmoid(v)
+    }
+    calibrated, err := calibration.CrossCalibrate(*calibName, splits, scores, yTrue)
+    if err != nil {
+        log.Fatal(err)
+    }
+    reports := []metrics.CalibrationReport{
+        metrics.EvaluateCalibration("uncalibrated", yTrue, raw, *calibBins),
+        metrics.EvaluateCalibration(*calibName, yTrue, calibrated, *calibBins),
+    }
+    fmt.Printf("\n%d 折折外概率的校准情况：\n", len(splits))
+    for _, r := range reports {
+        fmt.Print(r, "\n")
+    }
+    plotReliability(reports)
+
+    c, err := calibration.New(*calibName)
+    if err != nil {
+        log.Fatal(err)
+    }
+    if err := c.Fit(scores, yTrue); err != nil {
+        log.Fatal(err)
+    }
+    return &calibration.Classifier{Scorer: calibration.Logistic(b, w), Calibrator: c}
+}
+
+// plotReliability 把可靠性图保存为 reliability_plot.png
+func plotReliability(reports []metrics.CalibrationReport) {
+    p, err := calibration.ReliabilityPlot(reports)
+    if err != nil {
+        panic(err)
+    }
+    if err := p.Save(8*vg.Inch, 8*vg.Inch, "reliability_plot.png"); err != nil {
+        panic(err)
+    }
+    fmt.Println("可靠性图已保存为 reliability_plot.png")
+}
+
+// rawThreshold 把校准后概率上的阈值换算成未校准概率上的阈值，供 plotData 画出边界：
+// 校准是单调的，取校准概率不小于 threshold 的样本中最小的决策值；没有这样的样本时返回 1，不画边界
+func rawThreshold(decision, prob []float64, threshold float64) float64 {
+    g := math.Inf(1)
+    for i, p := range prob {
+        if p >= threshold {
+            g = math.Min(g, decision[i])
+        }
+    }
+    return logreg.Sigmoid(g)
+}
+
+// classCount 返回类别数，即最大的标签加 1，至少为 2
+func classCount(labels []int) int {
+    k := 2
+    for _, l := range labels {
+        k = max(k, l+1)
+    }
+    return k
+}
+
+// hyperparams 保存模型时一并记录的训练设置
+func hyperparams(scaler scale.Scaler, seed int64) map[string]interface{} {
+    return map[string]interface{}{
+        "lr": *lrFlag, "iterations": *iterFlag, "optimizer": *optimizerName,
+        "schedule": *scheduleName, "penalty": *penaltyName, "alpha": *alpha,
+        "l1_ratio": *l1Ratio, "scale": scaler.Name(), "seed": seed, "solver": *solverName,
+        "class_weight": *classWeight, "weight_col": *weightCol, "threshold": *thresholdName,
+        "calibrate": *calibName,
+    }
+}
+
+// probRows 把 n×k 的概率矩阵转换成 metrics 使用的按行切片，与 P 共享底层数组
+func probRows(P *mat.Dense) [][]float64 {
+    n, _ := P.Dims()
+    rows := make([][]float64, n)
+    for i := range rows {
+        rows[i] = P.RawRowView(i)
+    }
+    return rows
+}
+
+// Main 运行子命令，args 是子命令之后的命令行参数
+func Main(args []string) {
+    fs.Parse(args)
+    if *iterFlag < 1 {
+        log.Fatalf("-iterations 至少为 1，当前为 %d", *iterFlag)
+    }
+    seed := rng.Setup(*seedFlag)
+    opt, err := optim.New(*optimizerName)
+    if err != nil {
+        log.Fatal(err)
+    }
+
+    // 数据生成参数
+    const (
+        slope    = 1.342  // 真实直线斜率
+        intercept = 2.45  // 真实直线截距
+        lineGap  = 8.0    // 多分类时相邻分界线的截距之差
+    )
+
+    // 生成数据，二分类以一条直线为分界，多分类以 classes-1 条平行直线为分界；
+    // 指定 -data 时改为读取文件中的特征列（任意多个）和类别标签列
+    var table datasets.Table
+    var trueSlopes, trueIntercepts []float64
+    if *numClasses > 2 {
+        for j := 0; j < *numClasses-1; j++ {
+            trueSlopes = append(trueSlopes, slope)
+            trueIntercepts = append(trueIntercepts, intercept+float64(j)*lineGap)
+        }
+        table = datasets.MakeLines(rng.Source(seed), datasets.LinesConfig{
+            Samples:    *dataSize,
+            Slopes:     trueSlopes,
+            Intercepts: trueIntercepts,
+            XMin:       0.0,
+            XMax:       10.0,
+            YMin:       intercept - lineGap,
+            YMax:       slope*10 + trueIntercepts[len(trueIntercepts)-1] + lineGap,
+            Noise:      datasets.Uniform{Min: -*noiseMax / 2, Max: *noiseMax / 2},
+            FlipRatio:  *flipRatio,
+        }).Table()
+    } else {
+        table = datasets.MakeLine(rng.Source(seed), datasets.LineConfig{
+            Samples:   *dataSize,
+            Slope:     slope,
+            Intercept: intercept,
+            XMin:      0.0,
+            XMax:      10.0,
+            Noise:     datasets.Uniform{Min: -*noiseMax, Max: 2 * *noiseMax},
+            FlipRatio: *flipRatio,
+        }).Table()
+        trueSlopes, trueIntercepts = []float64{slope}, []float64{intercept}
+    }
+    csvTable, loaded, err := csvFlags.Load()
+    if err != nil {
+        log.Fatal(err)
+    }
+    if loaded {
+        // 文件中的数据没有真实分界线
+        table, trueSlopes, trueIntercepts = csvTable, nil, nil
+    }
+    if err := csvFlags.Save(table); err != nil {
+        log.Fatal(err)
+    }
+    // 样本权重列从特征中取出，不参与训练
+    var baseWeights []float64
+    if *weightCol != "" {
+        if baseWeights, table, err = table.TakeFeature(*weightCol); err != nil {
+            log.Fatal(err)
+        }
+        for _, v := range baseWeights {
+            if v < 0 {
+                log.Fatalf("样本权重列 %q 中有负数 %v", *weightCol, v)
+            }
+        }
+    }
+    X := table.X
+    yTrue, err := table.Labels()
+    if err != nil {
+        log.Fatal(err)
+    }
+    k := classCount(yTrue)
+    _, numFeatures := X.Dims()
+    weights := sampleWeights(yTrue, baseWeights, k)
+    if classWeights, _ := train.ClassWeights(*classWeight, yTrue, k); classWeights != nil {
+        fmt.Printf("%s 类别权重：%.4f\n", *classWeight, classWeights)
+    }
+
+    // 模型初始参数
+    b, w := 0.0, make([]float64, numFeatures)
+    learningRate := *lrFlag
+    iterations := *iterFlag
+    schedule, err := optim.NewSchedule(*scheduleName, learningRate, iterations, *warmupSteps)
+    if err != nil {
+        log.Fatal(err)
+    }
+
+    penalty, err := train.NewPenalty(*penaltyName, *alpha, *l1Ratio)
+    if err != nil {
+        log.Fatal(err)
+    }
+    penalty.PenalizeIntercept = *penalizeBias
+
+    // 迭代训练，每 10 次迭代打印一次损失，满足停止判据时提前结束
+    cfg := train.Config{
+        Schedule:  schedule,
+        Epochs:    iterations,
+        Optimizer: opt,
+        LogEvery:  10,
+        Penalty:   penalty,
+        Workers:   *workers,
+        Seed:      seed,
+        Stop: train.StopRule{
+            LossTol:  *lossTol,
+            GradTol:  *gradTol,
+            ParamTol: *paramTol,
+            Patience: *patience,
+        },
+    }
+    // 类别多于 2 个时改用 softmax 回归，交叉验证、评估、保存和画图都在 mainSoftmax 中完成
+    if k > 2 {
+        if *solverName != "gd" {
+            log.Fatalf("-solver %s 只支持二分类，共 %d 个类别时请使用 gd", *solverName, k)
+        }
+        if *thresholdName != "" || *calibName != "" {
+            log.Fatalf("-threshold 和 -calibrate 只支持二分类，共 %d 个类别", k)
+        }
+        mainSoftmax(table, yTrue, baseWeights, k, trueSlopes, trueIntercepts, cfg, seed)
+        return
+    }
+
+    // 交叉验证：每折从相同的初始参数出发，只用训练折训练（指定 -threshold 时也只在训练折上选阈值），
+    // 报告测试折准确率的均值 ± 标准差
+    if *numFolds >= 2 {
+        splits, err := cv.Splits(*cvMethod, rng.New(seed), len(yTrue), yTrue, *numFolds)
+        if err != nil {
+            log.Fatal(err)
+        }
+        scores := cv.CrossValidate(splits, func(s cv.Split) float64 {
+            trainX, trainY := cv.Rows(X, s.Train), cv.Ints(yTrue, s.Train)
+            foldWeights := sampleWeights(trainY, subsetWeights(baseWeights, s.Train), k)
+            fb, fw := fitBinary(trainX, trainY, foldWeights, b, w, foldConfig(cfg))
+            t := tuneThreshold(trainY, logreg.PredictProba(fb, fw, trainX))
+            pred := logreg.PredictClass(fb, fw, cv.Rows(X, s.Test), t.Threshold)
+            return metrics.Accuracy(cv.Ints(yTrue, s.Test), pred)
+        })
+        fmt.Printf("%s 交叉验证准确率：%v\n", *cvMethod, scores)
+    }
+
+    // 梯度下降打印每一轮的损失；newton 和 lbfgs 只打印迭代次数、最终损失和梯度范数
+    b0, w0 := b, w
+    var scaler scale.Scaler
+    var done solverRow
+    if *solverName == "gd" {
+        var hist train.History
+        start := time.Now()
+        b, w, hist, scaler = fitLogistic(X, yTrue, weights, b, w, cfg)
+        done = gdRow(hist, time.Since(start))
+        last := hist.Epochs() - 1
+        fmt.Printf("共迭代 %d 次，最终损失: J=%.4f，梯度范数: %.6g\n", hist.Epochs(), hist.Loss[last], hist.GradNorm[last])
+        if hist.StopReason != "" {
+            fmt.Printf("提前停止：%s\n", hist.StopReason)
+        }
+    } else {
+        var res logreg.SolveResult
+        b, w, res, scaler = solveLogistic(X, yTrue, weights, logreg.Solver(*solverName), cfg)
+        done = resultRow(res)
+        fmt.Println(res)
+    }
+    if *compare {
+        compareSolvers(X, yTrue, weights, b0, w0, cfg, done)
+    }
+
+    // 输出最终参数
+    fmt.Printf("训练后参数：b=%.4f, w=%.4f\n", b, w)
+
+    // 指定 -calibrate 时之后的阈值、指标和保存的模型都使用校准后的概率
+    prob := logreg.PredictProba(b, w, X)
+    var calibrator calibration.Calibrator
+    if *calibName != "" {
+        clf := calibrateLogistic(X, yTrue, baseWeights, b0, w0, b, w, cfg, seed)
+        calibrator, prob = clf.Calibrator, clf.PredictProba(X)
+    }
+
+    // 按 -threshold 选择阈值，并在全部样本上计算分类指标
+    threshold := tuneThreshold(yTrue, prob)
+    if threshold.Criterion != "" {
+        fmt.Printf("按 %s 选择的%s\n", threshold.Criterion, threshold)
+    }
+    report := metrics.EvaluateBinary(yTrue, prob, threshold.Threshold)
+    fmt.Print("\n", report)
+
+    // 保存缩放后特征空间中的参数和缩放器状态，预测时先缩放再套用参数
+    if *savePath != "" {
+        sb, sw := scaler.ScaleCoef(b, w)
+        m := &model.Model{
+            Kind:        model.Logistic,
+            Features:    table.FeatureNames,
+            Target:      table.TargetName,
+            Intercept:   sb,
+            Coef:        sw,
+            Threshold:   &threshold.Threshold,
+            Calibration: model.NewCalibration(calibrator),
+            Scaler:      model.NewScaler(scaler),
+            Hyperparams: hyperparams(scaler, seed),
+            Data:        model.NewFingerprint(table.X, table.Y),
+        }
+        if err := model.Save(*savePath, m); err != nil {
+            log.Fatal(err)
+        }
+        fmt.Printf("模型已保存到 %s\n", *savePath)
+    }
+
+    // 绘制图像，分类边界 b + w0*x + w1*y = 0 只能在两个特征时画出
+    if numFeatures != 2 {
+        fmt.Printf("共 %d 个特征，不绘制分类边界\n", numFeatures)
+        return
+    }
+    plotThreshold := threshold.Threshold
+    if calibrator != nil {
+        plotThreshold = rawThreshold(logreg.Decision(b, w, X), prob, threshold.Threshold)
+    }
+    plotData(mat.Col(nil, 0, X), mat.Col(nil, 1, X), yTrue, 2, trueSlopes, trueIntercepts,
+        []softmax.Boundary{{I: 1, J: 0, B: b, W: w}}, plotThreshold, report.Summary())
+}
+
+// mainSoftmax 用 softmax 回归完成 k 分类：交叉验证、训练、评估、保存模型，两个特征时画出每一对类别之间的边界
+func mainSoftmax(table datasets.Table, yTrue []int, baseWeights []float64, k int, trueSlopes, trueIntercepts []float64, cfg train.Config, seed int64) {
+    X := table.X
+    _, numFeatures := X.Dims()
+    fmt.Printf("共 %d 个类别，使用 softmax 回归\n", k)
+
+    if *numFolds >= 2 {
+        splits, err := cv.Splits(*cvMethod, rng.New(seed), len(yTrue), yTrue, *numFolds)
+        if err != nil {
+            log.Fatal(err)
+        }
+        scores := cv.CrossValidate(splits, func(s cv.Split) float64 {
+            trainY := cv.Ints(yTrue, s.Train)
+            foldWeights := sampleWeights(trainY, subsetWeights(baseWeights, s.Train), k)
+            fb, fW, _, _ := fitSoftmax(cv.Rows(X, s.Train), trainY, foldWeights, k, foldConfig(cfg))
+            pred := softmax.PredictClass(fb, fW, cv.Rows(X, s.Test))
+            return metrics.Accuracy(cv.Ints(yTrue, s.Test), pred)
+        })
+        fmt.Printf("%s 交叉验证准确率：%v\n", *cvMethod, scores)
+    }
+
+    b, W, hist, scaler := fitSoftmax(X, yTrue, sampleWeights(yTrue, baseWeights, k), k, cfg)
+    last := hist.Epochs() - 1
+    fmt.Printf("共迭代 %d 次，最终损失: J=%.4f，梯度范数: %.6g\n", hist.Epochs(), hist.Loss[last], hist.GradNorm[last])
+    if hist.StopReason != "" {
+        fmt.Printf("提前停止：%s\n", hist.StopReason)
+    }
+
+    // 输出最终参数，每个类别一行
+    fmt.Println("训练后参数：")
+    for c := 0; c < k; c++ {
+        fmt.Printf("  类别 %d：b=%.4f, w=%.4f\n", c, b[c], W.RawRowView(c))
+    }
+
+    report := metrics.EvaluateMulticlass(yTrue, probRows(softmax.PredictProba(b, W, X)))
+    fmt.Print("\n", report)
+
+    if *savePath != "" {
+        m := &model.Model{
+            Kind:        model.Softmax,
+            Features:    table.FeatureNames,
+            Target:      table.TargetName,
+            Intercepts:  make([]float64, k),
+            Weights:     make([][]float64, k),
+            Scaler:      model.NewScaler(scaler),
+            Hyperparams: hyperparams(scaler, seed),
+            Data:        model.NewFingerprint(table.X, table.Y),
+        }
+        for c := 0; c < k; c++ {
+            m.Intercepts[c], m.Weights[c] = scaler.ScaleCoef(b[c], W.RawRowView(c))
+        }
+        if err := model.Save(*savePath, m); err != nil {
+            log.Fatal(err)
+        }
+        fmt.Printf("模型已保存到 %s\n", *savePath)
+    }
+
+    if numFeatures != 2 {
+        fmt.Printf("共 %d 个特征，不绘制分类边界\n", numFeatures)
+        return
+    }
+    plotData(mat.Col(nil, 0, X), mat.Col(nil, 1, X), yTrue, k, trueSlopes, trueIntercepts,
+        softmax.Boundaries(b, W), 0.5, report.Summary())
+}
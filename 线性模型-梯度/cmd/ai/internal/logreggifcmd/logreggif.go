@@ -0,0 +1,315 @@
+// Package logreggifcmd 实现 ai logreg-gif 子命令：把逻辑回归（多分类时为 softmax 回归）的训练过程保存成 GIF 动画
+package logreggifcmd
+
+import (
+	"flag"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"log"
+	"os"
+	"runtime"
+
+	"fmt"
+
+	"ai/datasets"
+	"ai/logreg"
+	"ai/optim"
+	"ai/rng"
+	"ai/softmax"
+	"ai/train"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// 每个类别的颜色，二分类时 1 用红色、0 用蓝色
+var classColors = []color.RGBA{
+	{B: 255, A: 255},
+	{R: 255, A: 255},
+	{R: 255, G: 165, A: 255},
+	{G: 128, A: 255},
+	{G: 160, B: 160, A: 255},
+	{R: 139, G: 69, B: 19, A: 255},
+	{R: 255, B: 255, A: 255},
+	{R: 128, G: 128, A: 255},
+}
+
+func classColor(c int) color.RGBA {
+	return classColors[c%len(classColors)]
+}
+
+// boundaryColor 二分类的边界用绿色，多分类时类别 i 与 j 之间的边界取两者颜色的平均
+func boundaryColor(i, j, k int) color.RGBA {
+	if k == 2 {
+		return color.RGBA{G: 255, A: 255}
+	}
+	ci, cj := classColor(i), classColor(j)
+	return color.RGBA{R: uint8((int(ci.R) + int(cj.R)) / 2), G: uint8((int(ci.G) + int(cj.G)) / 2), B: uint8((int(ci.B) + int(cj.B)) / 2), A: 255}
+}
+
+// 绘制单帧图像（通过临时文件规避接口问题），k 个类别时画出每一对类别之间的边界
+func plotFrame(xData, yData []float64, labels []int, k int,
+	trueSlopes, trueIntercepts []float64, boundaries []softmax.Boundary, yMin, yMax float64, iteration int) image.Image {
+	// 创建绘图对象
+	p := plot.New()
+	p.Title.Text = fmt.Sprintf("Fitting Process (Iteration: %d)", iteration)
+	p.X.Label.Text = "X"
+	p.Y.Label.Text = "Y"
+
+	// 绘制真实分界线（黑色虚线）
+	for j, slope := range trueSlopes {
+		trueLineData := plotter.XYs{
+			{X: 0, Y: trueIntercepts[j]},
+			{X: 10, Y: slope*10 + trueIntercepts[j]},
+		}
+		trueLine, _ := plotter.NewLine(trueLineData)
+		trueLine.Color = color.RGBA{A: 255}
+		trueLine.Width = vg.Points(2)
+		trueLine.Dashes = []vg.Length{vg.Points(5), vg.Points(5)}
+		p.Add(trueLine)
+		if j == 0 {
+			p.Legend.Add("True Line", trueLine)
+		}
+	}
+
+	// 绘制数据点，每个类别一种颜色
+	for c := k - 1; c >= 0; c-- {
+		pts := make(plotter.XYs, 0)
+		for i := range xData {
+			if labels[i] == c {
+				pts = append(pts, plotter.XY{X: xData[i], Y: yData[i]})
+			}
+		}
+		scatter, _ := plotter.NewScatter(pts)
+		scatter.Color = classColor(c)
+		scatter.Radius = vg.Points(2)
+		p.Add(scatter)
+		p.Legend.Add(fmt.Sprintf("Class %d", c), scatter)
+	}
+
+	// 绘制拟合的分类边界 B + W0*x + W1*y = 0
+	for _, bd := range boundaries {
+		if bd.W[1] == 0 {
+			continue
+		}
+		fitLineData := plotter.XYs{
+			{X: 0, Y: (-bd.B - bd.W[0]*0) / bd.W[1]},
+			{X: 10, Y: (-bd.B - bd.W[0]*10) / bd.W[1]},
+		}
+		fitLine, _ := plotter.NewLine(fitLineData)
+		fitLine.Color = boundaryColor(bd.I, bd.J, k)
+		fitLine.Width = vg.Points(2)
+		p.Add(fitLine)
+		if k == 2 {
+			p.Legend.Add("Fitted Line", fitLine)
+		} else {
+			p.Legend.Add(fmt.Sprintf("Boundary %d|%d", bd.I, bd.J), fitLine)
+		}
+	}
+
+	// 坐标范围固定，各帧之间不会跳动
+	p.X.Min = -1
+	p.X.Max = 11
+	p.Y.Min = yMin
+	p.Y.Max = yMax
+
+	// 关键修正：通过临时文件保存再读取，规避接口不兼容
+	tempFile, err := os.CreateTemp("", "frame-*.png")
+	if err != nil {
+		panic(err)
+	}
+	tempFileName := tempFile.Name()
+	tempFile.Close()
+	defer os.Remove(tempFileName) // 清理临时文件
+
+	// 保存图像到临时文件
+	if err := p.Save(8*vg.Inch, 6*vg.Inch, tempFileName); err != nil {
+		panic(err)
+	}
+
+	// 从临时文件读取图像
+	f, err := os.Open(tempFileName)
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+	img, _, err := image.Decode(f)
+	if err != nil {
+		panic(err)
+	}
+	return img
+}
+
+// 合成GIF动画
+func saveGIF(frames []*image.Paletted, delay int, filename string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gifData := &gif.GIF{
+		Image: frames,
+		Delay: make([]int, len(frames)),
+	}
+	for i := range gifData.Delay {
+		gifData.Delay[i] = delay
+	}
+	return gif.EncodeAll(f, gifData)
+}
+
+// 转换为GIF所需格式，调色板包含 k 个类别和它们之间边界的颜色
+func toPaletted(img image.Image, k int) *image.Paletted {
+	bounds := img.Bounds()
+	palette := []color.Color{
+		color.Transparent,
+		color.RGBA{R: 0, G: 255, B: 0, A: 255}, // 绿色（二分类的边界）
+		color.RGBA{R: 0, G: 0, B: 0, A: 255},   // 黑色（文本）
+		color.RGBA{R: 255, G: 255, B: 255, A: 255}, // 白色（背景）
+	}
+	for i := 0; i < k; i++ {
+		palette = append(palette, classColor(i))
+		for j := i + 1; j < k && k > 2; j++ {
+			palette = append(palette, boundaryColor(i, j, k))
+		}
+	}
+	if len(palette) > 256 {
+		palette = palette[:256]
+	}
+	paletted := image.NewPaletted(bounds, palette)
+	draw.Draw(paletted, bounds, img, bounds.Min, draw.Src)
+	return paletted
+}
+
+var (
+	fs            = flag.NewFlagSet("logreg-gif", flag.ExitOnError)
+	optimizerName = fs.String("optimizer", "sgd", fmt.Sprintf("参数更新规则，可选：%v", optim.Names()))
+	scheduleName  = fs.String("schedule", "constant", fmt.Sprintf("学习率调度，可选：%v", optim.ScheduleNames()))
+	warmupSteps   = fs.Int("warmup", 0, "线性预热的步数，0 表示不预热")
+	workers       = fs.Int("workers", runtime.NumCPU(), "并行计算损失和梯度的 goroutine 数，1 为单线程")
+	seedFlag      = fs.Int64("seed", 1, rng.FlagUsage)
+	dataSize      = fs.Int("n", 2000, "合成数据的样本数")
+	noiseMax      = fs.Float64("noise-max", 3.554646, "噪声幅度，y 在真实直线上加 [-noise-max, 2*noise-max) 的均匀噪声；多分类时加 [-noise-max/2, noise-max/2) 的均匀噪声")
+	numClasses    = fs.Int("classes", 2, "类别数，大于 2 时用 classes-1 条平行直线划分类别，并训练 softmax 回归")
+	lrFlag        = fs.Float64("lr", 0.0008, "学习率")
+	iterFlag      = fs.Int("iterations", 180000, "迭代次数")
+	frameEvery    = fs.Int("frame-every", 100, "每隔多少次迭代绘制一帧")
+	outPath       = fs.String("out", "fitting_animation.gif", "GIF 动画的输出文件")
+)
+
+// Main 运行子命令，args 是子命令之后的命令行参数
+func Main(args []string) {
+	fs.Parse(args)
+	seed := rng.Setup(*seedFlag)
+	opt, err := optim.New(*optimizerName)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 数据参数
+	n, noiseMax := *dataSize, *noiseMax
+	const (
+		slope     = 1.342
+		intercept = 2.45
+		lineGap   = 8.0 // 多分类时相邻分界线的截距之差
+	)
+
+	// 二分类以一条直线为分界，多分类以 classes-1 条平行直线为分界
+	var xData, yData []float64
+	var yTrue []int
+	var trueSlopes, trueIntercepts []float64
+	yMin, yMax := -5.0, 20.0
+	k := max(*numClasses, 2)
+	if k > 2 {
+		for j := 0; j < k-1; j++ {
+			trueSlopes = append(trueSlopes, slope)
+			trueIntercepts = append(trueIntercepts, intercept+float64(j)*lineGap)
+		}
+		yMin, yMax = intercept-lineGap, slope*10+trueIntercepts[k-2]+lineGap
+		data := datasets.MakeLines(rng.Source(seed), datasets.LinesConfig{
+			Samples:    n,
+			Slopes:     trueSlopes,
+			Intercepts: trueIntercepts,
+			XMin:       0.0,
+			XMax:       10.0,
+			YMin:       yMin,
+			YMax:       yMax,
+			Noise:      datasets.Uniform{Min: -noiseMax / 2, Max: noiseMax / 2},
+		})
+		xData, yData, yTrue = data.XData, data.YData, data.Labels
+	} else {
+		data := datasets.MakeLine(rng.Source(seed), datasets.LineConfig{
+			Samples:   n,
+			Slope:     slope,
+			Intercept: intercept,
+			XMin:      0.0,
+			XMax:      10.0,
+			Noise:     datasets.Uniform{Min: -noiseMax, Max: 2 * noiseMax},
+		})
+		xData, yData, yTrue = data.XData, data.YData, data.Labels
+		trueSlopes, trueIntercepts = []float64{slope}, []float64{intercept}
+	}
+	X := datasets.Line{XData: xData, YData: yData, Labels: yTrue}.Table().X
+
+	learningRate := *lrFlag
+	iterations := *iterFlag
+	schedule, err := optim.NewSchedule(*scheduleName, learningRate, iterations, *warmupSteps)
+	if err != nil {
+		log.Fatal(err)
+	}
+	frameInterval := *frameEvery
+	frames := make([]*image.Paletted, 0)
+
+	// 损失和梯度在一次遍历中算出，并分片到多个 goroutine 上并行计算；
+	// 二分类的参数按 [a, b, c] 即 [偏置, x 的权重, y 的权重] 的顺序排列，多分类按 softmax.Pack 的顺序
+	var obj train.Objective
+	var params []float64
+	if k > 2 {
+		obj = train.NewParallel(softmax.Data{X: X, YTrue: yTrue, K: k}, *workers)
+		params = make([]float64, k+2*k)
+	} else {
+		obj = train.NewParallel(logreg.Data{X: X, YTrue: yTrue}, *workers)
+		params = make([]float64, 3)
+	}
+	all := train.All(n)
+
+	// boundaries 返回当前参数下要画出的分类边界
+	boundaries := func() []softmax.Boundary {
+		if k > 2 {
+			return softmax.Boundaries(softmax.Unpack(params, k))
+		}
+		return []softmax.Boundary{{I: 1, J: 0, B: params[0], W: params[1:]}}
+	}
+
+	// 训练并生成帧，参数交给优化器更新
+	grads := make([]float64, len(params))
+	for i := 0; i < iterations; i++ {
+		lr := schedule.Rate(i)
+		obj.LossGrad(params, all, grads)
+		opt.Step(params, grads, lr)
+
+		if i%frameInterval == 0 {
+			loss := obj.Loss(params, all)
+			fmt.Printf("迭代 %d 次，损失: J=%.4f，学习率: %.6g\n", i, loss, lr)
+			img := plotFrame(xData, yData, yTrue, k, trueSlopes, trueIntercepts, boundaries(), yMin, yMax, i)
+			frames = append(frames, toPaletted(img, k))
+		}
+	}
+
+	if k > 2 {
+		b, W := softmax.Unpack(params, k)
+		for c := 0; c < k; c++ {
+			fmt.Printf("训练后参数：类别 %d：b=%.4f, w=%.4f\n", c, b[c], W.RawRowView(c))
+		}
+	} else {
+		fmt.Printf("训练后参数：a=%.4f, b=%.4f, c=%.4f\n", params[0], params[1], params[2])
+	}
+
+	if err := saveGIF(frames, 15, *outPath); err != nil {
+		panic(err)
+	}
+	fmt.Printf("动画已保存为 %s\n", *outPath)
+}
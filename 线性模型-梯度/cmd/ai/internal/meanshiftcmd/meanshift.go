@@ -0,0 +1,280 @@
+// Package meanshiftcmd 实现 ai meanshift 子命令：用 Ebiten 动画展示均值漂移聚类的过程
+package meanshiftcmd
+
+import (
+	"flag"
+	"fmt"
+	"image/color"
+	"log"
+
+	"ai/cluster"
+	"ai/datasets"
+	"ai/model"
+	"ai/rng"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"golang.org/x/exp/rand"
+)
+
+// 生成带聚类特性的随机点（便于展示均值漂移效果）：
+// 聚类中心在 20-80 范围内随机选取，每个中心周围的点带有标准差为 8 的高斯噪声
+func generateClusteredPoints(src rand.Source, total int, clusters int) []cluster.Point {
+	blobs := datasets.MakeBlobs(src, datasets.BlobsConfig{
+		Samples:    total,
+		NumCenters: clusters,
+		Features:   2,
+		CenterMin:  20,
+		CenterMax:  80,
+		Noise:      datasets.Gaussian{Sigma: 8},
+	})
+
+	points := make([]cluster.Point, total)
+	for i := range points {
+		points[i] = cluster.Point{X: blobs.X.At(i, 0), Y: blobs.X.At(i, 1)}
+	}
+	return points
+}
+
+// Animation 均值漂移的动画状态：每个点从原始位置向当前模式点平滑移动
+type Animation struct {
+	ms           *cluster.MeanShift
+	currentModes []cluster.Point // 当前漂移位置（用于动画）
+	currentStep  int     // 当前动画步骤
+	converged    bool    // 是否收敛
+}
+
+func NewAnimation(points []cluster.Point, bandwidth float64) *Animation {
+	currentModes := make([]cluster.Point, len(points))
+	copy(currentModes, points)
+	return &Animation{
+		ms:           cluster.NewMeanShift(points, bandwidth),
+		currentModes: currentModes,
+	}
+}
+
+// 动画更新当前显示的模式点（平滑过渡）
+func (a *Animation) UpdateAnimation(progress float64) {
+	ms := a.ms
+	for i := range a.currentModes {
+		// 线性插值实现平滑动画
+		a.currentModes[i].X = ms.Points[i].X + (ms.Modes[i].X-ms.Points[i].X)*progress
+		a.currentModes[i].Y = ms.Points[i].Y + (ms.Modes[i].Y-ms.Points[i].Y)*progress
+	}
+
+	// 收敛后计算聚类标签（合并相似的模式点）
+	if a.converged {
+		ms.Merge()
+	}
+}
+
+// 可视化窗口
+type Game struct {
+	anim      *Animation
+	width     int
+	height    int
+	animSpeed float64
+	animProg  float64
+
+	onConverged func(*Game) // 收敛时调用一次，为 nil 时不调用
+}
+
+func NewGame(points []cluster.Point, bandwidth float64) *Game {
+	return &Game{
+		anim:      NewAnimation(points, bandwidth),
+		width:     800,
+		height:    600,
+		animSpeed: 0.03,
+		animProg:  0,
+	}
+}
+
+func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return g.width, g.height
+}
+
+func (g *Game) Update() error {
+	if g.anim.converged {
+		return nil
+	}
+	
+	// 动画进度更新
+	g.animProg += g.animSpeed
+	if g.animProg >= 1.0 {
+		// 动画结束，执行下一步均值漂移
+		g.animProg = 0
+		g.anim.converged = g.anim.ms.Step()
+		g.anim.currentStep++
+	}
+	
+	// 更新当前动画帧的显示状态
+	g.anim.UpdateAnimation(g.animProg)
+	if g.anim.converged && g.onConverged != nil {
+		g.onConverged(g)
+	}
+	return nil
+}
+
+func (g *Game) Draw(screen *ebiten.Image) {
+	// 白色背景
+	screen.Fill(color.White)
+
+	// 定义聚类颜色
+	// 定义聚类颜色
+	colors := []color.Color{
+		color.RGBA{255, 0, 0, 255},    // 红色
+		color.RGBA{0, 255, 0, 255},    // 绿色
+		color.RGBA{0, 0, 255, 255},    // 蓝色
+		color.RGBA{255, 165, 0, 255},  // 橙色
+		color.RGBA{128, 0, 128, 255},  // 紫色
+		color.RGBA{0, 255, 255, 255},  // 青色
+		color.RGBA{255, 255, 0, 255},  // 黄色
+		color.RGBA{128, 128, 128, 255}, // 灰色
+		color.RGBA{18, 218, 18, 255},  
+		color.RGBA{181, 28, 8, 255}, 
+		color.RGBA{81, 32, 48, 255},  
+		color.RGBA{231,54, 88, 255},  
+	}
+
+	// 绘制原始数据点（灰色小点点）
+	for _, p := range g.anim.ms.Points {
+		x := int(p.X * float64(g.width) / 100)
+		y := int(p.Y * float64(g.height) / 100)
+		for dx := -1; dx <= 1; dx++ {
+			for dy := -1; dy <= 1; dy++ {
+				screen.Set(x+dx, y+dy, color.Gray{Y: 200})
+			}
+		}
+	}
+
+	// 绘制漂移轨迹线（浅色）
+	for i := range g.anim.ms.Points {
+		start := g.anim.ms.Points[i]
+		current := g.anim.currentModes[i]
+		startX := int(start.X * float64(g.width) / 100)
+		startY := int(start.Y * float64(g.height) / 100)
+		currentX := int(current.X * float64(g.width) / 100)
+		currentY := int(current.Y * float64(g.height) / 100)
+		drawLine(screen, startX, startY, currentX, currentY, color.Gray{Y: 150})
+	}
+
+	// 绘制当前模式点（带聚类颜色）
+	for i, m := range g.anim.currentModes {
+		var c color.Color
+		if g.anim.converged {
+			// 收敛后按聚类着色
+			c = colors[g.anim.ms.Labels[i]%len(colors)]
+		} else {
+			// 收敛前用统一颜色
+			c = color.RGBA{0, 0, 255, 200}
+		}
+		
+		x := int(m.X * float64(g.width) / 100)
+		y := int(m.Y * float64(g.height) / 100)
+		for dx := -2; dx <= 2; dx++ {
+			for dy := -2; dy <= 2; dy++ {
+				screen.Set(x+dx, y+dy, c)
+			}
+		}
+	}
+
+	// 显示算法状态
+	status := fmt.Sprintf("均值漂移聚类 - 迭代: %d, 带宽: %.1f", g.anim.ms.Iterations, g.anim.ms.Bandwidth)
+	if g.anim.converged {
+		status += " - 已收敛！"
+	}
+	ebitenutil.DebugPrint(screen, status)
+}
+
+// 绘制线段的辅助函数
+func drawLine(screen *ebiten.Image, x0, y0, x1, y1 int, c color.Color) {
+	dx := abs(x1 - x0)
+	dy := abs(y1 - y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	err := dx - dy
+
+	for {
+		screen.Set(x0, y0, c)
+		if x0 == x1 && y0 == y1 {
+			break
+		}
+		e2 := 2 * err
+		if e2 > -dy {
+			err -= dy
+			x0 += sx
+		}
+		if e2 < dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+var (
+	fs        = flag.NewFlagSet("meanshift", flag.ExitOnError)
+	seedFlag  = fs.Int64("seed", 1, rng.FlagUsage)
+	dataSize  = fs.Int("n", 600, "合成数据的点数")
+	numBlobs  = fs.Int("clusters", 5, "合成数据的自然聚类个数")
+	bandwidth = fs.Float64("bandwidth", 5, "带宽（核函数半径），按窗口的 0-100 坐标计，控制聚类粒度")
+	csvFlags  = datasets.RegisterCSVFlags(fs, "")
+	savePath  = fs.String("save", "", "收敛后把聚类中心保存到该 JSON 文件，可用 ai predict 加载")
+)
+
+// Main 运行均值漂移聚类动画，args 是子命令之后的命令行参数
+func Main(args []string) {
+	fs.Parse(args)
+	seed := rng.Setup(*seedFlag)
+
+	// 生成带聚类特性的点
+	points := generateClusteredPoints(rng.Source(seed), *dataSize, *numBlobs)
+	table, loaded, err := csvFlags.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	// 合成数据本身就在 0-100 的坐标系中，窗口坐标与原始单位相同
+	view := cluster.Identity()
+	if loaded {
+		if points, view, err = cluster.FromTable(table); err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		table = cluster.ToTable(points)
+	}
+	if err := csvFlags.Save(table); err != nil {
+		log.Fatal(err)
+	}
+
+	// 初始化均值漂移
+	game := NewGame(points, *bandwidth)
+	if *savePath != "" {
+		game.onConverged = func(g *Game) {
+			m := cluster.NewModel(model.MeanShift, g.anim.ms.Centers, view, table, map[string]interface{}{
+				"bandwidth": g.anim.ms.Bandwidth, "iterations": g.anim.ms.Iterations, "seed": seed,
+			})
+			if err := model.Save(*savePath, m); err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("模型已保存到 %s\n", *savePath)
+		}
+	}
+	ebiten.SetWindowSize(game.width, game.height)
+	ebiten.SetWindowTitle(fmt.Sprintf("均值漂移聚类动画 (seed=%d)", seed))
+
+	// 运行动画
+	if err := ebiten.RunGame(game); err != nil {
+		panic(err)
+	}
+}
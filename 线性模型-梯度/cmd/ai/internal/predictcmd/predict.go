@@ -0,0 +1,143 @@
+// Package predictcmd 实现 ai predict 子命令：用保存的模型对 CSV 文件做预测
+package predictcmd
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"ai/datasets"
+	"ai/metrics"
+	"ai/model"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// 用其他子命令以 -save 保存的模型对 CSV 文件做预测：
+//
+//	ai predict -model model.json -data input.csv -out predictions.csv
+//
+// 特征列按模型中记录的列名从文件中读取；文件中有目标列时顺便打印评估指标
+var (
+	fs         = flag.NewFlagSet("predict", flag.ExitOnError)
+	modelPath  = fs.String("model", "", "模型文件（由各子命令的 -save 生成）")
+	dataPath   = fs.String("data", "", "待预测的 CSV/TSV 文件")
+	outPath    = fs.String("out", "", "预测结果写入的 CSV/TSV 文件，为空时输出到标准输出")
+	headerMode = fs.String("header", "auto", "第一行是否是表头：auto, yes, no")
+	missing    = fs.String("missing", string(datasets.MissingSkip), fmt.Sprintf("特征缺失值的处理方式，可选：%v", datasets.MissingNames()))
+)
+
+// Main 运行子命令，args 是子命令之后的命令行参数
+func Main(args []string) {
+	fs.Parse(args)
+	if *modelPath == "" || *dataPath == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	m, err := model.Load(*modelPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	header, err := datasets.ParseHeaderMode(*headerMode)
+	if err != nil {
+		log.Fatal(err)
+	}
+	opts := datasets.CSVOptions{Header: header, Features: m.Features, Target: m.Target, Missing: datasets.Missing(*missing)}
+	t, err := datasets.LoadCSV(*dataPath, opts)
+	if err != nil && m.Target != "" {
+		// 待预测的文件通常没有目标列，此时只读取特征
+		opts.Target = ""
+		t, err = datasets.LoadCSV(*dataPath, opts)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	rows, _ := t.X.Dims()
+	fmt.Fprintf(os.Stderr, "%s 模型，从 %s 读取 %d 个样本，丢弃 %d 行，填补 %d 个缺失值\n",
+		m.Kind, *dataPath, rows, len(t.Skipped), t.Imputed)
+
+	// 与训练数据的指纹比较，提示是否就是训练时用的那份数据
+	y := t.Y
+	if m.Target == "" {
+		y = nil
+	}
+	if model.NewFingerprint(t.X, y) == m.Data {
+		fmt.Fprintln(os.Stderr, "输入数据与训练数据的指纹一致，以下是训练集上的结果")
+	}
+
+	p, err := m.Predict(t.X)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if t.Y != nil {
+		switch m.Kind {
+		case model.Linear:
+			fmt.Fprint(os.Stderr, metrics.EvaluateRegression(t.Y, p.Values, len(m.Features)))
+		case model.Logistic:
+			labels, err := t.Labels()
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Fprint(os.Stderr, metrics.EvaluateBinary(labels, p.Values, m.DecisionThreshold()))
+		case model.Softmax:
+			labels, err := t.Labels()
+			if err != nil {
+				log.Fatal(err)
+			}
+			for _, l := range labels {
+				if l >= len(m.Weights) {
+					log.Fatalf("目标列 %q 中有类别 %d，模型只有 %d 个类别", t.TargetName, l, len(m.Weights))
+				}
+			}
+			fmt.Fprint(os.Stderr, metrics.EvaluateMulticlass(labels, p.Proba))
+		}
+	}
+
+	if err := writePredictions(*outPath, t, m.Kind, p); err != nil {
+		log.Fatal(err)
+	}
+	if *outPath != "" {
+		fmt.Fprintf(os.Stderr, "预测结果已写入 %s\n", *outPath)
+	}
+}
+
+// writePredictions 在特征列后追加预测列写出：线性回归为 prediction，
+// 逻辑回归为 probability 和 label，softmax 回归为预测类别的 probability 和 label，聚类为 cluster；path 为空时写到标准输出
+func writePredictions(path string, t datasets.Table, kind model.Kind, p model.Prediction) error {
+	rows, cols := t.X.Dims()
+	names := append([]string(nil), t.FeatureNames...)
+	var extra [][]float64
+	switch kind {
+	case model.Linear:
+		names = append(names, "prediction")
+		extra = append(extra, p.Values)
+	case model.Logistic, model.Softmax:
+		names = append(names, "probability", "label")
+		extra = append(extra, p.Values, intsToFloats(p.Labels))
+	default:
+		names = append(names, "cluster")
+		extra = append(extra, intsToFloats(p.Labels))
+	}
+
+	X := mat.NewDense(rows, cols+len(extra), nil)
+	X.Slice(0, rows, 0, cols).(*mat.Dense).Copy(t.X)
+	for j, col := range extra {
+		X.SetCol(cols+j, col)
+	}
+	out := datasets.Table{X: X, FeatureNames: names}
+	if path == "" {
+		return datasets.WriteCSV(os.Stdout, out, ',')
+	}
+	return datasets.SaveCSV(path, out)
+}
+
+func intsToFloats(v []int) []float64 {
+	f := make([]float64, len(v))
+	for i, x := range v {
+		f[i] = float64(x)
+	}
+	return f
+}
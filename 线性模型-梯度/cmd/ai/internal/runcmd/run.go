@@ -0,0 +1,51 @@
+// Package runcmd 实现 ai run 子命令：按 YAML/JSON 实验文件生成数据、训练、评估并画图
+package runcmd
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"ai/experiment"
+)
+
+// 依次运行命令行中给出的每个实验文件：
+//
+//	ai run experiments/linreg.yaml experiments/logreg.yaml
+//
+// 实验文件的格式见 experiment.Config，experiments 目录下有示例
+var (
+	fs        = flag.NewFlagSet("run", flag.ExitOnError)
+	checkOnly = fs.Bool("check", false, "只解析和检查实验文件，不运行")
+)
+
+// Main 运行子命令，args 是子命令之后的命令行参数
+func Main(args []string) {
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "用法：ai run [参数] <实验文件>...\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	for i, path := range fs.Args() {
+		cfg, err := experiment.Load(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if *checkOnly {
+			fmt.Printf("%s: 实验 %s 检查通过\n", path, cfg.Name)
+			continue
+		}
+		if i > 0 {
+			fmt.Println()
+		}
+		if _, err := experiment.Run(cfg); err != nil {
+			log.Fatalf("%s: %v", path, err)
+		}
+	}
+}
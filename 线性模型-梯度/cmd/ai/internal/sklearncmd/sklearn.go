@@ -0,0 +1,310 @@
+// Package sklearncmd 实现 ai sklearn-logreg 子命令：训练 pa-m/sklearn 的逻辑回归并画出分类边界
+package sklearncmd
+
+import (
+	"flag"
+	"fmt"
+	 
+	"log"
+	    "image/color" 
+ 
+    "gonum.org/v1/plot"
+    "gonum.org/v1/plot/plotter"
+    "gonum.org/v1/plot/vg"
+ 
+	 
+"github.com/pa-m/sklearn/linear_model"
+	"github.com/pa-m/sklearn/base"
+
+	"ai/calibration"
+	"ai/cv"
+	"ai/datasets"
+	"ai/logreg"
+	"ai/metrics"
+	"ai/model"
+	"ai/rng"
+	//"github.com/pa-m/sklearn/datasets"
+ 
+	"gonum.org/v1/gonum/mat"
+	 
+)
+
+var _ base.Predicter = &linearmodel.LogisticRegression{}
+var fs = flag.NewFlagSet("sklearn-logreg", flag.ExitOnError)
+var visualDebug = fs.Bool("visual", false, "output images for benchmarks and test data")
+var seedFlag = fs.Int64("seed", 1, rng.FlagUsage)
+var testRatio = fs.Float64("test-ratio", 0.2, "按类别分层留作测试集的样本比例")
+var cvMethod = fs.String("cv", "stratified", fmt.Sprintf("交叉验证方式，可选：%v", cv.Methods()))
+var numFolds = fs.Int("folds", 5, "交叉验证的折数，<2 时不做交叉验证")
+var dataSize = fs.Int("n", 2000, "合成数据的样本数")
+var noiseMax = fs.Float64("noise-max", 1.554646, "噪声幅度，y 在真实直线上加 [-noise-max, 2*noise-max) 的均匀噪声")
+var maxIter = fs.Int("iterations", 10000, "最大迭代次数")
+var savePath = fs.String("save", "", "把训练好的模型保存到该 JSON 文件，可用 ai predict 加载")
+var calibName = fs.String("calibrate", "", fmt.Sprintf("概率校准方法，可选：%v，为空时不校准；校准器在训练集的折外得分上拟合，在测试集上评估", calibration.Names()))
+var calibFolds = fs.Int("calib-folds", 5, "拟合校准器时计算折外得分所用的折数，划分方式同 -cv")
+var calibBins = fs.Int("calib-bins", 10, "可靠性图把 [0, 1] 等分成的区间数")
+
+// calibrateModel 在训练集上用 -calib-folds 折交叉验证得到每个样本的折外得分，每折克隆一份 regr 重新训练；
+// 在折外得分上拟合 -calibrate 指定的校准器并包装 regr，然后在测试集上比较校准前后的 Brier 分数和可靠性曲线，
+// 画出可靠性图，返回拟合好的校准器
+func calibrateModel(regr *linearmodel.LogisticRegression, Xtrain, ytrain *mat.Dense, trainLabels []int,
+	Xtest *mat.Dense, testLabels []int, seed int64) calibration.Calibrator {
+	splits, err := cv.Splits(*cvMethod, rng.New(seed), len(trainLabels), trainLabels, *calibFolds)
+	if err != nil {
+		log.Fatal(err)
+	}
+	scores := make([]float64, len(trainLabels))
+	for _, s := range splits {
+		m := regr.PredicterClone().(*linearmodel.LogisticRegression)
+		if err := cv.Fit(m, cv.Rows(Xtrain, s.Train), cv.Rows(ytrain, s.Train)); err != nil {
+			log.Print(err)
+		}
+		for k, v := range calibration.FromProbas(m)(cv.Rows(Xtrain, s.Test)) {
+			scores[s.Test[k]] = v
+		}
+	}
+
+	c, err := calibration.New(*calibName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := c.Fit(scores, trainLabels); err != nil {
+		log.Fatal(err)
+	}
+	clf := &calibration.Classifier{Scorer: calibration.FromProbas(regr), Calibrator: c}
+
+	raw := regr.PredictProbas(Xtest, nil)
+	reports := []metrics.CalibrationReport{
+		metrics.EvaluateCalibration("uncalibrated", testLabels, mat.Col(nil, 0, raw), *calibBins),
+		metrics.EvaluateCalibration(*calibName, testLabels, clf.PredictProba(Xtest), *calibBins),
+	}
+	fmt.Print("\n测试集概率的校准情况：\n")
+	for _, r := range reports {
+		fmt.Print(r, "\n")
+	}
+
+	p, err := calibration.ReliabilityPlot(reports)
+	if err != nil {
+		panic(err)
+	}
+	if err := p.Save(8*vg.Inch, 8*vg.Inch, "sklearn_reliability_plot.png"); err != nil {
+		panic(err)
+	}
+	fmt.Println("可靠性图已保存为 sklearn_reliability_plot.png")
+	return c
+}
+
+// 转换数据为模型所需的矩阵格式
+func prepareData(xData, yData []float64) *mat.Dense {
+	n := len(xData)
+	data := make([]float64, 0, n*2) // 每行2个特征：[x, y]
+	for i := 0; i < n; i++ {
+		data = append(data, xData[i], yData[i])
+	}
+	return mat.NewDense(n, 2, data) // n行2列矩阵
+}
+// Main 运行子命令，args 是子命令之后的命令行参数
+func Main(args []string) {
+	fs.Parse(args)
+	seed := rng.Setup(*seedFlag)
+
+	// 数据参数
+	n, noiseMax := *dataSize, *noiseMax
+	const (
+		slope     = 1.342
+		intercept = 2.45
+	)
+
+	// 生成数据
+	data := datasets.MakeLine(rng.Source(seed), datasets.LineConfig{
+		Samples:   n,
+		Slope:     slope,
+		Intercept: intercept,
+		XMin:      0.0,
+		XMax:      10.0,
+		Noise:     datasets.Uniform{Min: -noiseMax, Max: 2 * noiseMax},
+	})
+	xData, yData, labels := data.XData, data.YData, data.Labels
+
+	// 准备特征矩阵（每行2个特征：x和y）
+	X := prepareData(xData, yData)
+
+//	fmt.Println(xData, yData)
+
+	// 转换标签为float64类型（模型要求）
+	y := make([]float64, n)
+	for i, label := range labels {
+		y[i] = float64(label)
+	}
+	yMat := mat.NewDense(n, 1, y) // 转换为列矩阵
+
+	// adapted from http://scikit-learn.org/stable/_downloads/plot_iris_logistic.ipynb
+	//ds := datasets.LoadIris()
+
+	// we only take the first _ features.
+	//nSamples, _ := ds.X.Dims()
+	//X, YTrueClasses := ds.X.Slice(0, nSamples, 0, 2).(*mat.Dense), ds.Y
+ 
+
+	regr := linearmodel.NewLogisticRegression()
+	regr.Alpha = 1e-5
+	regr.RandomState = rng.Source(seed)
+	regr.Tol = 0.0032
+
+	regr.MaxIter  = *maxIter
+	regr.NIterNoChange = 10
+ 
+
+	log.SetPrefix("ExampleLogisticRegression_Fit_iris:")
+	defer log.SetPrefix("")
+
+	// 按类别分层划分训练集和测试集，准确率在没有参与训练的测试集上计算
+	split := cv.StratifiedTrainTestSplit(rng.New(seed), labels, *testRatio)
+	Xtrain, ytrain := cv.Rows(X, split.Train), cv.Rows(yMat, split.Train)
+	Xtest, ytest := cv.Rows(X, split.Test), cv.Rows(yMat, split.Test)
+
+	// 交叉验证：报告各折测试准确率的均值 ± 标准差
+	if *numFolds >= 2 {
+		splits, err := cv.Splits(*cvMethod, rng.New(seed), n, labels, *numFolds)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("%s 交叉验证准确率：%v\n", *cvMethod, cv.CrossValidate(splits, cv.PredicterFold(regr, X, yMat)))
+	}
+
+	// we create an instance of our Classifier and fit the data.
+	if err := cv.Fit(regr, Xtrain, ytrain); err != nil {
+		log.Print(err)
+	}
+	//regr.Fit(X, YTrueClasses)
+
+	accuracy := regr.Score(Xtest, ytest)
+	if accuracy >= 0.833 {
+		fmt.Println("ok")
+	} else {
+		fmt.Printf("Accuracy:%.3f\n", accuracy)
+	}
+
+	// 在测试集上计算分类指标，正类概率由模型参数按 logistic 函数算出
+	a, b, c := regr.Intercept[0], regr.Coef.Data[0], regr.Coef.Data[1]
+	testProb := logreg.PredictProba(a, []float64{b, c}, Xtest)
+	report := metrics.EvaluateBinary(cv.Ints(labels, split.Test), testProb, 0.5)
+	fmt.Print("\n测试集指标：\n", report)
+
+	var calibrator calibration.Calibrator
+	if *calibName != "" {
+		calibrator = calibrateModel(regr, Xtrain, ytrain, cv.Ints(labels, split.Train), Xtest, cv.Ints(labels, split.Test), seed)
+	}
+
+	if *savePath != "" {
+		m := &model.Model{
+			Kind:        model.Logistic,
+			Features:    []string{"x", "y"},
+			Target:      "label",
+			Intercept:   a,
+			Coef:        []float64{b, c},
+			Calibration: model.NewCalibration(calibrator),
+			Hyperparams: map[string]interface{}{
+				"library": "pa-m/sklearn", "alpha": regr.Alpha, "tol": regr.Tol,
+				"max_iter": regr.MaxIter, "n_iter_no_change": regr.NIterNoChange,
+				"test_ratio": *testRatio, "seed": seed, "calibrate": *calibName,
+			},
+			Data: model.NewFingerprint(Xtrain, mat.Col(nil, 0, ytrain)),
+		}
+		if err := model.Save(*savePath, m); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("模型已保存到 %s\n", *savePath)
+	}
+
+	plotData(xData, yData,labels, slope, intercept, regr.Intercept[0], regr.Coef.Data       [0], regr.Coef .Data       [1], report)
+
+
+	fmt.Println("sklearn逻辑回归模型参数：")
+	fmt.Printf("偏置项 a = %.4f\n", regr.Intercept[0])   // 对应 a
+	fmt.Printf("特征系数  %.4f \n",  regr.Coef.Data)
+}
+
+
+// 绘制图像，包括原始数据、真实直线、训练后模型拟合的直线（这里简单用最终参数绘制近似直线示意）
+func plotData(xData, yData []float64, labels []int, 
+    trueSlope, trueIntercept, a, b, c float64, report metrics.ClassificationReport) {
+    p := plot.New()
+ 
+    p.Title.Text = "Data Distribution and Fitted Line (test " + report.Summary() + ")"
+    p.X.Label.Text = "X"
+    p.Y.Label.Text = "Y"
+
+    // 绘制真实直线
+    // trueLineData := make(plotter.XYs, 2)
+    // trueLineData[0] = plotter.XY{X: 0, Y: trueIntercept}
+    // trueLineData[1] = plotter.XY{X: 10, Y: trueSlope*10 + trueIntercept}
+    // trueLine, err := plotter.NewLine(trueLineData)
+    // if err != nil {
+    //     panic(err)
+    // }
+    // trueLine.Color = color.RGBA{R: 0, G: 0, B: 255, A: 255}
+    // trueLine.Width = vg.Points(2)
+    // p.Add(trueLine)
+    // p.Legend.Add("True Line (y=1.342x+2.45)", trueLine)
+
+    // 绘制数据点，区分上下方（这里简单用颜色区分，1用红色，0用蓝色）
+    scatterUp := make(plotter.XYs, 0)
+    scatterDown := make(plotter.XYs, 0)
+    for i := range xData {
+        if labels[i] == 1 {
+            scatterUp = append(scatterUp, plotter.XY{X: xData[i], Y: yData[i]})
+        } else {
+            scatterDown = append(scatterDown, plotter.XY{X: xData[i], Y: yData[i]})
+        }
+    }
+    // 上方点
+    upPlotter, err := plotter.NewScatter(scatterUp)
+    if err != nil {
+        panic(err)
+    }
+    upPlotter.Color = color.RGBA{R: 255, G: 0, B: 0, A: 255}
+    upPlotter.Radius = vg.Points(3)
+    p.Add(upPlotter)
+    p.Legend.Add("Above Line (1)", upPlotter)
+    // 下方点
+    downPlotter, err := plotter.NewScatter(scatterDown)
+    if err != nil {
+        panic(err)
+    }
+    downPlotter.Color = color.RGBA{R: 0, G: 0, B: 255, A: 255}
+    downPlotter.Radius = vg.Points(3)
+    p.Add(downPlotter)
+    p.Legend.Add("Below Line (0)", downPlotter)
+
+    // 绘制拟合的直线（这里用 logistic 函数的线性部分近似，简单绘制示意，实际是分类边界）
+    // 拟合的线性关系是 g(x,y)=a + b*x + c*y，这里为了可视化，固定 y 或者做简化，假设 y 取数据中的值等，
+    // 这里简单绘制当 g(x,y)=0 时的直线近似（分类边界），实际逻辑回归是二分类，边界是 g(x,y)=0 即 a + b*x + c*y = 0
+    // 为了在 2D 图像展示，假设 y 是另一个维度，这里简化处理，比如取 y 为数据中的均值等，或者固定一个值，这里简单演示
+    // 以下只是示意，实际根据你的模型理解调整可视化方式，比如如果是多元逻辑回归，可视化会复杂些，这里因为数据生成是基于 y = 1.342x + 2.45 + noise，
+    // 可以近似认为拟合的是类似的线性关系，简单绘制 a + b*x + c*y = 0 的直线（分类边界）
+    fitLineData := make(plotter.XYs, 2)
+    // 找两个点绘制直线，这里简单取 x 范围端点，计算对应的 y
+    x1, x2 := 0.0, 10.0
+    // 假设 y 是数据集中的 y 维度，这里为了绘图，假设我们想展示在原始数据的 y 维度上的边界，可能需要调整，
+    // 以下计算是 a + b*x + c*y = 0 → y = (-a - b*x)/c （c≠0时）
+    if c != 0 {
+        y1 := (-a - b*x1) / c
+        y2 := (-a - b*x2) / c
+        fitLineData[0] = plotter.XY{X: x1, Y: y1}
+        fitLineData[1] = plotter.XY{X: x2, Y: y2}
+        fitLine, err := plotter.NewLine(fitLineData)
+        if err != nil {
+            panic(err)
+        }
+        fitLine.Color = color.RGBA{R: 12, G: 25, B: 0, A: 215}
+        fitLine.Width = vg.Points(2)
+        p.Add(fitLine)
+        p.Legend.Add("Fitted Line", fitLine)
+    }
+
+    if err := p.Save(10*vg.Inch, 8*vg.Inch, "result_plot111.png"); err != nil {
+        panic(err)
+    }
+    fmt.Println("图像已保存为 result_plot111.png")
+}
\ No newline at end of file
@@ -1,11 +1,15 @@
-package main
+// Package sklearnfitcmd 实现 ai sklearn-logreg-fit 子命令：训练 pa-m/sklearn 的逻辑回归并打印训练集、测试集准确率和模型参数
+package sklearnfitcmd
 
 import (
+	"flag"
 	"fmt"
 	//"image/color"
 	//"math"
-	"math/rand"
-	"time"
+
+	"ai/cv"
+	"ai/datasets"
+	"ai/rng"
 
 	"github.com/pa-m/sklearn/linear_model"
 	"gonum.org/v1/gonum/mat"
@@ -14,27 +18,6 @@ import (
 	//"gonum.org/v1/plot/vg"
 )
 
-// 生成数据：x数组、y数组、标签（1=上方，0=下方）
-func generateData(n int, slope, intercept, noiseMax float64) ([]float64, []float64, []int) {
-	rand.Seed(time.Now().UnixNano())
-	x := make([]float64, n)
-	y := make([]float64, n)
-	labels := make([]int, n)
-	xMin, xMax := 0.0, 10.0
-	for i := 0; i < n; i++ {
-		x[i] = xMin + rand.Float64()*(xMax-xMin)
-		noise := (rand.Float64()*3 - 1) * noiseMax
-		y[i] = slope*x[i] + intercept + noise
-		trueY := slope*x[i] + intercept
-		if y[i] > trueY {
-			labels[i] = 1
-		} else {
-			labels[i] = 0
-		}
-	}
-	return x, y, labels
-}
-
 // 转换数据为模型所需的矩阵格式
 func prepareData(xData, yData []float64) *mat.Dense {
 	n := len(xData)
@@ -125,17 +108,39 @@ func prepareData(xData, yData []float64) *mat.Dense {
 // 	fmt.Println("图像已保存为 sklearn_result_plot.png")
 // }
 
-func main() {
+var (
+	fs        = flag.NewFlagSet("sklearn-logreg-fit", flag.ExitOnError)
+	seedFlag  = fs.Int64("seed", 1, rng.FlagUsage)
+	testRatio = fs.Float64("test-ratio", 0.2, "按类别分层留作测试集的样本比例")
+	cvMethod  = fs.String("cv", "stratified", fmt.Sprintf("交叉验证方式，可选：%v", cv.Methods()))
+	numFolds  = fs.Int("folds", 5, "交叉验证的折数，<2 时不做交叉验证")
+	dataSize  = fs.Int("n", 2000, "合成数据的样本数")
+	noiseMax  = fs.Float64("noise-max", 3.554646, "噪声幅度，y 在真实直线上加 [-noise-max, 2*noise-max) 的均匀噪声")
+	maxIter   = fs.Int("iterations", 200000, "最大迭代次数")
+)
+
+// Main 运行子命令，args 是子命令之后的命令行参数
+func Main(args []string) {
+	fs.Parse(args)
+	seed := rng.Setup(*seedFlag)
+
 	// 数据参数
+	n, noiseMax := *dataSize, *noiseMax
 	const (
-		n         = 2000
 		slope     = 1.342
 		intercept = 2.45
-		noiseMax  = 3.554646
 	)
 
 	// 生成数据
-	xData, yData, labels := generateData(n, slope, intercept, noiseMax)
+	data := datasets.MakeLine(rng.Source(seed), datasets.LineConfig{
+		Samples:   n,
+		Slope:     slope,
+		Intercept: intercept,
+		XMin:      0.0,
+		XMax:      10.0,
+		Noise:     datasets.Uniform{Min: -noiseMax, Max: 2 * noiseMax},
+	})
+	xData, yData, labels := data.XData, data.YData, data.Labels
 
 	// 准备特征矩阵（每行2个特征：x和y）
 	X := prepareData(xData, yData)
@@ -150,20 +155,37 @@ func main() {
 	// 创建逻辑回归模型（根据文档：NewLogisticRegression返回*LogisticRegression）
 	model := linearmodel.NewLogisticRegression()
 	model.Alpha = 1e-5
+	model.RandomState = rng.Source(seed)
 
 	// 配置模型参数（文档说明：通过结构体字段直接设置）
-	model.MaxIter = 200000 // 最大迭代次数
+	model.MaxIter = *maxIter // 最大迭代次数
 	//model.Tol = 1e-8       // 收敛容差（可选）
 
+	// 按类别分层划分训练集和测试集，只在训练集上训练，在没见过的测试集上评估
+	split := cv.StratifiedTrainTestSplit(rng.New(seed), labels, *testRatio)
+	Xtrain, ytrain := cv.Rows(X, split.Train), cv.Rows(yMat, split.Train)
+	Xtest, ytest := cv.Rows(X, split.Test), cv.Rows(yMat, split.Test)
+
+	// 交叉验证：每折克隆一份未训练的模型，报告各折测试准确率的均值 ± 标准差
+	if *numFolds >= 2 {
+		splits, err := cv.Splits(*cvMethod, rng.New(seed), n, labels, *numFolds)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Printf("%s 交叉验证准确率：%v\n", *cvMethod, cv.CrossValidate(splits, cv.PredicterFold(model, X, yMat)))
+	}
+
 	// 训练模型（文档说明：Fit方法接收特征矩阵X和标签矩阵y）
-	if err := model.Fit(X, yMat); err != nil {
-		panic(err)
+	if err := cv.Fit(model, Xtrain, ytrain); err != nil {
+		fmt.Printf("警告：%v\n", err)
 	}
+	fmt.Printf("训练集准确率：%.4f，测试集准确率：%.4f（训练 %d 个样本，测试 %d 个样本）\n",
+		model.Score(Xtrain, ytrain), model.Score(Xtest, ytest), len(split.Train), len(split.Test))
 
 	// 输出模型参数（文档说明：模型参数存储在Coef和Intercept字段）
 	fmt.Println("sklearn逻辑回归模型参数：")
 	fmt.Printf("偏置项 a = %.4f\n", model.Intercept[0])   // 对应 a
-	fmt.Printf("特征系数  %.4f \n",  model.Coef ) // 对应 b 和 c
+	fmt.Printf("特征系数  %.4f \n",  model.Coef.Data) // 对应 b 和 c
 
 	// 绘制图像
 	//plotData(xData, yData, labels, slope, intercept, model)
@@ -0,0 +1,83 @@
+// Command ai 把各个演示程序合并成一个命令行工具，每个演示是一个子命令：
+//
+//	ai <子命令> [参数]
+//	ai logreg -scale standard -lr 0.5 -iterations 3000
+//	ai kmeans -k 4 -n 500
+//	ai run experiments/logreg.yaml
+//
+// 每个子命令的参数可以用 ai <子命令> -h 查看。
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"ai/cmd/ai/internal/basiscmd"
+	"ai/cmd/ai/internal/diabetescmd"
+	"ai/cmd/ai/internal/gradcheckcmd"
+	"ai/cmd/ai/internal/kmeanscmd"
+	"ai/cmd/ai/internal/linedatacmd"
+	"ai/cmd/ai/internal/linregcmd"
+	"ai/cmd/ai/internal/linregmulticmd"
+	"ai/cmd/ai/internal/linregvizcmd"
+	"ai/cmd/ai/internal/logregcmd"
+	"ai/cmd/ai/internal/logreggifcmd"
+	"ai/cmd/ai/internal/meanshiftcmd"
+	"ai/cmd/ai/internal/predictcmd"
+	"ai/cmd/ai/internal/runcmd"
+	"ai/cmd/ai/internal/sklearncmd"
+	"ai/cmd/ai/internal/sklearnfitcmd"
+)
+
+// command 一个子命令，Main 接收子命令名之后的参数
+type command struct {
+	Name  string
+	Usage string
+	Main  func(args []string)
+}
+
+var commands = []command{
+	{"linreg", "单特征线性回归，梯度下降训练，可与最小二乘闭式解对比", linregcmd.Main},
+	{"linreg-multi", "多特征线性回归，支持正则化和坐标下降", linregmulticmd.Main},
+	{"linreg-viz", "用 Ebiten 实时展示线性回归的梯度下降过程", linregvizcmd.Main},
+	{"basis", "多项式、RBF、傅里叶特征展开拟合曲线", basiscmd.Main},
+	{"sgd-diabetes", "在 diabetes 数据集上训练 pa-m/sklearn 的 SGDRegressor", diabetescmd.Main},
+	{"line-data", "生成以直线为分界的二分类数据并画图", linedatacmd.Main},
+	{"gradcheck", "用中心差分检查逻辑回归的梯度", gradcheckcmd.Main},
+	{"logreg", "任意特征数的逻辑回归，类别多于 2 个时用 softmax 回归，两个特征时画出分类边界", logregcmd.Main},
+	{"logreg-gif", "把逻辑回归（或多分类的 softmax 回归）的训练过程保存成 GIF 动画", logreggifcmd.Main},
+	{"sklearn-logreg", "pa-m/sklearn 逻辑回归，画出分类边界并可保存模型", sklearncmd.Main},
+	{"sklearn-logreg-fit", "pa-m/sklearn 逻辑回归，打印训练集、测试集准确率和参数", sklearnfitcmd.Main},
+	{"kmeans", "k-means 聚类动画", kmeanscmd.Main},
+	{"meanshift", "均值漂移聚类动画", meanshiftcmd.Main},
+	{"predict", "用 -save 保存的模型对 CSV 文件做预测", predictcmd.Main},
+	{"run", "按 YAML/JSON 实验文件训练、评估并画图", runcmd.Main},
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "用法：ai <子命令> [参数]，ai <子命令> -h 查看子命令的参数\n\n子命令：\n")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-20s %s\n", c.Name, c.Usage)
+	}
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	name := os.Args[1]
+	if name == "help" || name == "-h" || name == "-help" || name == "--help" {
+		usage()
+		return
+	}
+	for _, c := range commands {
+		if c.Name == name {
+			c.Main(os.Args[2:])
+			return
+		}
+	}
+	fmt.Fprintf(os.Stderr, "未知的子命令 %q\n\n", name)
+	usage()
+	os.Exit(2)
+}
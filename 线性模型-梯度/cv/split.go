@@ -0,0 +1,193 @@
+// Package cv 划分训练集和测试集，并做交叉验证。
+//
+// 所有划分函数只返回样本下标（Split），不复制数据，因此同一套划分既可以用于
+// linreg、logreg 中手写的训练函数，也可以用于 pa-m/sklearn 的模型：
+// 用 Rows、Floats、Ints 按下标取出子集即可。打乱和分层所用的随机数
+// 来自调用方传入的 *rand.Rand，传入 rng.New(seed) 即可复现同一套划分。
+package cv
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Split 一次划分的训练集和测试集下标，两者都按升序排列
+type Split struct {
+	Train, Test []int
+}
+
+// TrainTestSplit 打乱 0..n-1 后取出 testRatio 比例的样本作为测试集；rnd 为 nil 时不打乱，取最后的样本
+func TrainTestSplit(rnd *rand.Rand, n int, testRatio float64) Split {
+	order := permutation(rnd, n)
+	nTest := int(math.Round(testRatio * float64(n)))
+	return newSplit(n, order[n-nTest:])
+}
+
+// StratifiedTrainTestSplit 按类别分层划分：每个类别各自取出 testRatio 比例的样本作为测试集，
+// 使测试集的类别比例与全体样本一致
+func StratifiedTrainTestSplit(rnd *rand.Rand, labels []int, testRatio float64) Split {
+	var test []int
+	for _, idx := range groupByLabel(rnd, labels) {
+		nTest := int(math.Round(testRatio * float64(len(idx))))
+		test = append(test, idx[len(idx)-nTest:]...)
+	}
+	return newSplit(len(labels), test)
+}
+
+// KFold 把 0..n-1 分成 k 折，依次用每一折作测试集、其余作训练集；
+// n 不能被 k 整除时前 n%k 折各多一个样本。rnd 为 nil 时不打乱，按原顺序连续分折
+func KFold(rnd *rand.Rand, n, k int) ([]Split, error) {
+	if k < 2 || k > n {
+		return nil, fmt.Errorf("cv: 折数 %d 必须在 [2, %d] 之间", k, n)
+	}
+	order := permutation(rnd, n)
+	folds := make([][]int, k)
+	start := 0
+	for f := range folds {
+		size := n / k
+		if f < n%k {
+			size++
+		}
+		folds[f] = order[start : start+size]
+		start += size
+	}
+	return foldSplits(n, folds), nil
+}
+
+// StratifiedKFold 分层 k 折：每个类别的样本打乱后轮流分到各折，使每折的类别比例与全体样本一致
+func StratifiedKFold(rnd *rand.Rand, labels []int, k int) ([]Split, error) {
+	n := len(labels)
+	if k < 2 || k > n {
+		return nil, fmt.Errorf("cv: 折数 %d 必须在 [2, %d] 之间", k, n)
+	}
+	folds := make([][]int, k)
+	f := 0
+	// 折号在类别之间连续递增，避免每个类别多出来的样本都落在前几折
+	for _, idx := range groupByLabel(rnd, labels) {
+		for _, i := range idx {
+			folds[f] = append(folds[f], i)
+			f = (f + 1) % k
+		}
+	}
+	return foldSplits(n, folds), nil
+}
+
+// LeaveOneOut 留一法：每次只留一个样本作测试集，共 n 次划分
+func LeaveOneOut(n int) []Split {
+	splits := make([]Split, n)
+	for i := range splits {
+		splits[i] = newSplit(n, []int{i})
+	}
+	return splits
+}
+
+// Methods 返回 Splits 支持的交叉验证方式
+func Methods() []string {
+	return []string{"kfold", "stratified", "loo"}
+}
+
+// Splits 按名称生成交叉验证的划分，供命令行选择；stratified 需要 labels，其余方式只用到 n
+func Splits(method string, rnd *rand.Rand, n int, labels []int, k int) ([]Split, error) {
+	switch method {
+	case "kfold":
+		return KFold(rnd, n, k)
+	case "stratified":
+		if labels == nil {
+			return nil, fmt.Errorf("cv: 分层划分需要类别标签")
+		}
+		return StratifiedKFold(rnd, labels, k)
+	case "loo":
+		return LeaveOneOut(n), nil
+	}
+	return nil, fmt.Errorf("cv: 未知的交叉验证方式 %q，可选：%v", method, Methods())
+}
+
+// Rows 取出 X 中下标为 idx 的行
+func Rows(X mat.Matrix, idx []int) *mat.Dense {
+	_, cols := X.Dims()
+	sub := mat.NewDense(len(idx), cols, nil)
+	for r, i := range idx {
+		for j := 0; j < cols; j++ {
+			sub.Set(r, j, X.At(i, j))
+		}
+	}
+	return sub
+}
+
+// Floats 取出 v 中下标为 idx 的元素
+func Floats(v []float64, idx []int) []float64 {
+	sub := make([]float64, len(idx))
+	for r, i := range idx {
+		sub[r] = v[i]
+	}
+	return sub
+}
+
+// Ints 取出 v 中下标为 idx 的元素
+func Ints(v []int, idx []int) []int {
+	sub := make([]int, len(idx))
+	for r, i := range idx {
+		sub[r] = v[i]
+	}
+	return sub
+}
+
+// permutation 返回 0..n-1 的随机排列，rnd 为 nil 时按原顺序
+func permutation(rnd *rand.Rand, n int) []int {
+	if rnd == nil {
+		order := make([]int, n)
+		for i := range order {
+			order[i] = i
+		}
+		return order
+	}
+	return rnd.Perm(n)
+}
+
+// groupByLabel 按类别分组，每组内的下标被打乱，组按类别从小到大排列
+func groupByLabel(rnd *rand.Rand, labels []int) [][]int {
+	byLabel := make(map[int][]int)
+	for _, i := range permutation(rnd, len(labels)) {
+		byLabel[labels[i]] = append(byLabel[labels[i]], i)
+	}
+	keys := make([]int, 0, len(byLabel))
+	for label := range byLabel {
+		keys = append(keys, label)
+	}
+	sort.Ints(keys)
+	groups := make([][]int, len(keys))
+	for g, label := range keys {
+		groups[g] = byLabel[label]
+	}
+	return groups
+}
+
+// foldSplits 依次用每一折作测试集生成划分
+func foldSplits(n int, folds [][]int) []Split {
+	splits := make([]Split, len(folds))
+	for f, test := range folds {
+		splits[f] = newSplit(n, test)
+	}
+	return splits
+}
+
+// newSplit 以 test 为测试集、其余样本为训练集生成划分
+func newSplit(n int, test []int) Split {
+	inTest := make([]bool, n)
+	for _, i := range test {
+		inTest[i] = true
+	}
+	s := Split{Train: make([]int, 0, n-len(test)), Test: make([]int, 0, len(test))}
+	for i, ok := range inTest {
+		if ok {
+			s.Test = append(s.Test, i)
+		} else {
+			s.Train = append(s.Train, i)
+		}
+	}
+	return s
+}
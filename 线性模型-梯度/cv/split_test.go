@@ -0,0 +1,174 @@
+package cv
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+)
+
+// imbalancedLabels 返回类别 0、1、2 分别有 23、7、11 个样本、顺序打乱的标签
+func imbalancedLabels() []int {
+	var labels []int
+	for c, count := range []int{23, 7, 11} {
+		for i := 0; i < count; i++ {
+			labels = append(labels, c)
+		}
+	}
+	rand.New(rand.NewSource(1)).Shuffle(len(labels), func(i, j int) { labels[i], labels[j] = labels[j], labels[i] })
+	return labels
+}
+
+// checkSplit 检查 s 的训练集和测试集都按升序排列、互不相交，并且合起来恰好是 0..n-1
+func checkSplit(t *testing.T, name string, s Split, n int) {
+	t.Helper()
+	if !sort.IntsAreSorted(s.Train) || !sort.IntsAreSorted(s.Test) {
+		t.Errorf("%s: 下标没有按升序排列", name)
+	}
+	seen := make([]int, n)
+	for _, i := range append(append([]int(nil), s.Train...), s.Test...) {
+		seen[i]++
+	}
+	for i, c := range seen {
+		if c != 1 {
+			t.Errorf("%s: 样本 %d 在训练集和测试集中共出现 %d 次", name, i, c)
+		}
+	}
+}
+
+func TestFoldsPartition(t *testing.T) {
+	labels := imbalancedLabels()
+	must := func(splits []Split, err error) []Split {
+		if err != nil {
+			t.Fatal(err)
+		}
+		return splits
+	}
+	tests := []struct {
+		name   string
+		n      int
+		splits []Split
+	}{
+		{"kfold", 10, must(KFold(rand.New(rand.NewSource(1)), 10, 3))},
+		{"kfold/unshuffled", 7, must(KFold(nil, 7, 7))},
+		{"stratified", len(labels), must(StratifiedKFold(rand.New(rand.NewSource(2)), labels, 4))},
+		{"loo", 5, LeaveOneOut(5)},
+	}
+	for _, tt := range tests {
+		// 各折的测试集合起来恰好覆盖每个样本一次
+		inTest := make([]int, tt.n)
+		for f, s := range tt.splits {
+			checkSplit(t, tt.name, s, tt.n)
+			for _, i := range s.Test {
+				inTest[i]++
+			}
+			if len(s.Test) == 0 {
+				t.Errorf("%s: 第 %d 折的测试集为空", tt.name, f)
+			}
+		}
+		for i, c := range inTest {
+			if c != 1 {
+				t.Errorf("%s: 样本 %d 出现在 %d 个测试折中", tt.name, i, c)
+			}
+		}
+	}
+}
+
+func TestKFoldSizes(t *testing.T) {
+	splits, err := KFold(nil, 10, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 10 不能被 3 整除，第一折多一个样本；不打乱时按原顺序连续分折
+	want := [][]int{{0, 1, 2, 3}, {4, 5, 6}, {7, 8, 9}}
+	for f, s := range splits {
+		if !slices.Equal(s.Test, want[f]) {
+			t.Errorf("第 %d 折的测试集为 %v，应为 %v", f, s.Test, want[f])
+		}
+	}
+	for _, k := range []int{1, 11} {
+		if _, err := KFold(nil, 10, k); err == nil {
+			t.Errorf("KFold 没有拒绝 %d 折", k)
+		}
+	}
+}
+
+func TestStratifiedKFoldRatios(t *testing.T) {
+	labels := imbalancedLabels()
+	counts := classCounts(labels, nil)
+	for _, k := range []int{2, 3, 4, 7} {
+		splits, err := StratifiedKFold(rand.New(rand.NewSource(int64(k))), labels, k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// 每折中每个类别的样本数与 n_c/k 相差不到 1
+		for f, s := range splits {
+			fold := classCounts(labels, s.Test)
+			for c, total := range counts {
+				if got, want := fold[c], float64(total)/float64(k); math.Abs(float64(got)-want) >= 1 {
+					t.Errorf("k=%d 第 %d 折：类别 %d 有 %d 个样本，应接近 %.2f", k, f, c, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestLeaveOneOut(t *testing.T) {
+	const n = 6
+	splits := LeaveOneOut(n)
+	if len(splits) != n {
+		t.Fatalf("留一法有 %d 折，应为 %d", len(splits), n)
+	}
+	for i, s := range splits {
+		if !slices.Equal(s.Test, []int{i}) || len(s.Train) != n-1 {
+			t.Errorf("第 %d 折：测试集 %v，训练集 %d 个样本", i, s.Test, len(s.Train))
+		}
+	}
+}
+
+func TestTrainTestSplit(t *testing.T) {
+	tests := []struct {
+		n        int
+		ratio    float64
+		wantTest int
+	}{
+		{100, 0.2, 20},
+		{10, 0.25, 3}, // 2.5 四舍五入为 3
+		{7, 0, 0},
+		{7, 1, 7},
+	}
+	for _, tt := range tests {
+		s := TrainTestSplit(rand.New(rand.NewSource(1)), tt.n, tt.ratio)
+		checkSplit(t, "TrainTestSplit", s, tt.n)
+		if len(s.Test) != tt.wantTest {
+			t.Errorf("n=%d ratio=%g: 测试集有 %d 个样本，应为 %d", tt.n, tt.ratio, len(s.Test), tt.wantTest)
+		}
+	}
+
+	// 分层划分时每个类别各自按比例取测试样本
+	labels := imbalancedLabels()
+	s := StratifiedTrainTestSplit(rand.New(rand.NewSource(1)), labels, 0.3)
+	checkSplit(t, "StratifiedTrainTestSplit", s, len(labels))
+	test := classCounts(labels, s.Test)
+	for c, total := range classCounts(labels, nil) {
+		if got, want := test[c], int(math.Round(0.3*float64(total))); got != want {
+			t.Errorf("分层划分：类别 %d 的测试样本有 %d 个，应为 %d", c, got, want)
+		}
+	}
+}
+
+// classCounts 统计 idx 中各类别的样本数，idx 为 nil 时统计全部样本
+func classCounts(labels, idx []int) map[int]int {
+	counts := make(map[int]int)
+	if idx == nil {
+		for _, y := range labels {
+			counts[y]++
+		}
+		return counts
+	}
+	for _, i := range idx {
+		counts[labels[i]]++
+	}
+	return counts
+}
@@ -0,0 +1,95 @@
+package cv
+
+import (
+	"fmt"
+	"log"
+	"math"
+
+	"github.com/pa-m/sklearn/base"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Fold 在 s.Train 上训练模型并返回它在 s.Test 上的评分
+type Fold func(s Split) float64
+
+// Scores 各折的评分
+type Scores []float64
+
+// Mean 返回各折评分的均值
+func (s Scores) Mean() float64 {
+	sum := 0.0
+	for _, v := range s {
+		sum += v
+	}
+	return sum / float64(len(s))
+}
+
+// Std 返回各折评分的总体标准差（与 scikit-learn 中 scores.std() 一致）
+func (s Scores) Std() float64 {
+	mean := s.Mean()
+	sum := 0.0
+	for _, v := range s {
+		sum += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(sum / float64(len(s)))
+}
+
+// String 按 "均值 ± 标准差 (折数)" 的格式输出
+func (s Scores) String() string {
+	return fmt.Sprintf("%.4f ± %.4f (%d folds)", s.Mean(), s.Std(), len(s))
+}
+
+// CrossValidate 对每个划分调用 fit，返回各折的评分
+func CrossValidate(splits []Split, fit Fold) Scores {
+	scores := make(Scores, len(splits))
+	for f, s := range splits {
+		scores[f] = fit(s)
+	}
+	return scores
+}
+
+// Fit 调用 model.Fit，并把 pa-m/sklearn 在 lbfgs 线搜索失败时抛出的 panic 转换成 error。
+// 线性可分的数据上损失会一直趋向 0，线搜索最终无法再前进而失败；此时模型保留最后一次
+// 迭代的参数，仍然可以预测，相当于 scikit-learn 中的 ConvergenceWarning
+func Fit(model base.Fiter, X, Y mat.Matrix) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("cv: 模型训练未收敛: %v", r)
+		}
+	}()
+	model.Fit(X, Y)
+	return nil
+}
+
+// PredicterFold 把 pa-m/sklearn 的模型包装成 Fold：每折克隆一份未训练的 model，
+// 在训练集上 Fit，返回测试集上的 Score（分类器为准确率，回归器为 R²）；
+// 训练未收敛时打印警告并照常评分。回归器不要与留一法一起使用，见 PredictOutOfFold
+func PredicterFold(model base.Predicter, X, Y mat.Matrix) Fold {
+	return func(s Split) float64 {
+		m := model.PredicterClone()
+		if err := Fit(m, Rows(X, s.Train), Rows(Y, s.Train)); err != nil {
+			log.Print(err)
+		}
+		return m.Score(Rows(X, s.Test), Rows(Y, s.Test))
+	}
+}
+
+// PredictOutOfFold 对每个划分克隆一份未训练的 model，在训练集上 Fit 后预测测试集，
+// 返回每个样本由没有见过它的模型给出的预测值（同 scikit-learn 的 cross_val_predict）。
+// 留一法每折只有一个测试样本，逐折的 R² 没有定义，应当在全部折外预测上计算一次
+func PredictOutOfFold(model base.Predicter, X, Y mat.Matrix, splits []Split) *mat.Dense {
+	n, cols := Y.Dims()
+	out := mat.NewDense(n, cols, nil)
+	for _, s := range splits {
+		m := model.PredicterClone()
+		if err := Fit(m, Rows(X, s.Train), Rows(Y, s.Train)); err != nil {
+			log.Print(err)
+		}
+		pred := mat.NewDense(len(s.Test), cols, nil)
+		m.Predict(Rows(X, s.Test), pred)
+		for k, i := range s.Test {
+			out.SetRow(i, pred.RawRowView(k))
+		}
+	}
+	return out
+}
@@ -0,0 +1,257 @@
+package datasets
+
+import (
+	"math"
+
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Classification 分类或聚类数据集及其真实结构
+type Classification struct {
+	X       *mat.Dense  // 特征矩阵，每行是一个样本
+	Labels  []int       // 类别标签 0..k-1（包含翻转后的噪声标签）
+	Centers [][]float64 // 每个类别的真实中心，moons 和 circles 为 nil
+	Flipped []int       // 标签被随机翻转的样本下标
+}
+
+// BlobsConfig make_blobs 的配置：围绕若干中心生成各向同性的高斯团
+type BlobsConfig struct {
+	Samples              int
+	Centers              [][]float64 // 指定的聚类中心，为 nil 时在 [CenterMin, CenterMax) 内随机选取 NumCenters 个
+	NumCenters           int
+	Features             int // 随机选取中心时的维数
+	CenterMin, CenterMax float64
+	Noise                Noise // 每个坐标相对中心的偏移，为 nil 时是标准差为 1 的高斯噪声
+	FlipRatio            float64
+}
+
+// MakeBlobs 按 cfg 生成聚类数据，样本尽量平均地分给各个中心
+func MakeBlobs(src rand.Source, cfg BlobsConfig) Classification {
+	rnd := rand.New(src)
+
+	centers := cfg.Centers
+	if centers == nil {
+		centers = make([][]float64, cfg.NumCenters)
+		for k := range centers {
+			centers[k] = make([]float64, cfg.Features)
+			for j := range centers[k] {
+				centers[k][j] = cfg.CenterMin + rnd.Float64()*(cfg.CenterMax-cfg.CenterMin)
+			}
+		}
+	}
+	noise := cfg.Noise
+	if noise == nil {
+		noise = Gaussian{Sigma: 1}
+	}
+
+	dims := len(centers[0])
+	X := mat.NewDense(cfg.Samples, dims, nil)
+	labels := make([]int, cfg.Samples)
+	for i := range labels {
+		// 第 i 个样本属于第 i*k/n 个中心，前面的中心在除不尽时多分到一个样本
+		k := i * len(centers) / cfg.Samples
+		row := X.RawRowView(i)
+		for j := range row {
+			row[j] = centers[k][j] + noise.Sample(rnd, centers[k])
+		}
+		labels[i] = k
+	}
+
+	flipped := FlipLabels(rnd, labels, len(centers), cfg.FlipRatio)
+	return Classification{X: X, Labels: labels, Centers: centers, Flipped: flipped}
+}
+
+// MakeMoons 生成两个交错的半圆，n 个样本平均分给两类；
+// noise 加在每个坐标上，为 nil 时样本恰好落在半圆上
+func MakeMoons(src rand.Source, n int, noise Noise) Classification {
+	rnd := rand.New(src)
+	X := mat.NewDense(n, 2, nil)
+	labels := make([]int, n)
+	nOuter := n / 2
+	for i := 0; i < n; i++ {
+		var p []float64
+		if i < nOuter {
+			t := math.Pi * float64(i) / math.Max(float64(nOuter-1), 1)
+			p = []float64{math.Cos(t), math.Sin(t)}
+		} else {
+			t := math.Pi * float64(i-nOuter) / math.Max(float64(n-nOuter-1), 1)
+			p = []float64{1 - math.Cos(t), 0.5 - math.Sin(t)}
+			labels[i] = 1
+		}
+		addNoise(rnd, p, noise)
+		X.SetRow(i, p)
+	}
+	return Classification{X: X, Labels: labels}
+}
+
+// MakeCircles 生成两个同心圆，外圆半径为 1、标签为 0，内圆半径为 factor、标签为 1
+func MakeCircles(src rand.Source, n int, factor float64, noise Noise) Classification {
+	rnd := rand.New(src)
+	X := mat.NewDense(n, 2, nil)
+	labels := make([]int, n)
+	nOuter := n / 2
+	for i := 0; i < n; i++ {
+		radius, k, count := 1.0, i, nOuter
+		if i >= nOuter {
+			radius, k, count = factor, i-nOuter, n-nOuter
+			labels[i] = 1
+		}
+		t := 2 * math.Pi * float64(k) / float64(count)
+		p := []float64{radius * math.Cos(t), radius * math.Sin(t)}
+		addNoise(rnd, p, noise)
+		X.SetRow(i, p)
+	}
+	return Classification{X: X, Labels: labels}
+}
+
+// ClassificationConfig make_classification 的简化版配置：
+// 每个类别的中心位于边长为 2*ClassSep 的超立方体的一个顶点上，样本在中心周围加噪声生成
+type ClassificationConfig struct {
+	Samples   int
+	Features  int
+	Classes   int
+	ClassSep  float64
+	Noise     Noise // 为 nil 时是标准差为 1 的高斯噪声
+	FlipRatio float64
+}
+
+// MakeClassification 按 cfg 生成多类别分类数据
+func MakeClassification(src rand.Source, cfg ClassificationConfig) Classification {
+	rnd := rand.New(src)
+
+	// 从 2^Features 个顶点中为每个类别随机选一个，顶点够用时互不重复；维数较高时只在前 62 维上选
+	vertexBits := cfg.Features
+	if vertexBits > 62 {
+		vertexBits = 62
+	}
+	numVertices := uint64(1) << vertexBits
+	used := make(map[uint64]bool)
+	centers := make([][]float64, cfg.Classes)
+	for k := range centers {
+		v := rnd.Uint64() % numVertices
+		for used[v] && uint64(len(used)) < numVertices {
+			v = rnd.Uint64() % numVertices
+		}
+		used[v] = true
+		centers[k] = make([]float64, cfg.Features)
+		for j := range centers[k] {
+			centers[k][j] = -cfg.ClassSep
+			if j < vertexBits && v&(1<<j) != 0 {
+				centers[k][j] = cfg.ClassSep
+			}
+		}
+	}
+
+	return MakeBlobs(rand.NewSource(rnd.Uint64()), BlobsConfig{
+		Samples:   cfg.Samples,
+		Centers:   centers,
+		Noise:     cfg.Noise,
+		FlipRatio: cfg.FlipRatio,
+	})
+}
+
+// LineConfig 以直线 y = Slope*x + Intercept 为分界的二维二分类数据的配置：
+// x 在 [XMin, XMax) 内均匀采样，y 在直线上加噪声，落在直线上方的样本标签为 1
+type LineConfig struct {
+	Samples          int
+	Slope, Intercept float64
+	XMin, XMax       float64
+	Noise            Noise // 加在 y 上的噪声，标签按加噪声后的位置确定；为 nil 时不加，所有点都落在直线上、标签为 0
+	FlipRatio        float64
+}
+
+// Line 以直线为分界的二分类数据集，坐标分别存放，便于 logreg 直接使用
+type Line struct {
+	XData, YData     []float64
+	Labels           []int // 1 表示在直线上方，0 表示在直线下方（包含翻转后的噪声标签）
+	Slope, Intercept float64
+	Flipped          []int
+}
+
+// MakeLine 按 cfg 生成以直线为分界的二分类数据
+func MakeLine(src rand.Source, cfg LineConfig) Line {
+	rnd := rand.New(src)
+	d := Line{
+		XData:     make([]float64, cfg.Samples),
+		YData:     make([]float64, cfg.Samples),
+		Labels:    make([]int, cfg.Samples),
+		Slope:     cfg.Slope,
+		Intercept: cfg.Intercept,
+	}
+	for i := 0; i < cfg.Samples; i++ {
+		x := cfg.XMin + rnd.Float64()*(cfg.XMax-cfg.XMin)
+		trueY := cfg.Slope*x + cfg.Intercept
+		d.XData[i] = x
+		d.YData[i] = trueY
+		if cfg.Noise != nil {
+			d.YData[i] += cfg.Noise.Sample(rnd, []float64{x})
+		}
+		if d.YData[i] > trueY {
+			d.Labels[i] = 1
+		}
+	}
+	d.Flipped = FlipLabels(rnd, d.Labels, 2, cfg.FlipRatio)
+	return d
+}
+
+// LinesConfig 以若干条直线 y = Slopes[j]*x + Intercepts[j] 为分界的二维多分类数据的配置：
+// x 在 [XMin, XMax) 内、y 在 [YMin, YMax) 内均匀采样，样本的标签是它位于其上方的直线条数，
+// 共 len(Slopes)+1 个类别；直线在 x 的范围内最好互不相交，否则有的类别可能没有样本
+type LinesConfig struct {
+	Samples            int
+	Slopes, Intercepts []float64
+	XMin, XMax         float64
+	YMin, YMax         float64
+	Noise              Noise // 确定标签后加在 y 上的噪声，使相邻类别在分界线附近交叠；为 nil 时不加
+	FlipRatio          float64
+}
+
+// Lines 以若干条直线为分界的多分类数据集，坐标分别存放，与 Line 相同
+type Lines struct {
+	XData, YData       []float64
+	Labels             []int // 0..len(Slopes)，即样本位于其上方的直线条数（包含翻转后的噪声标签）
+	Slopes, Intercepts []float64
+	Flipped            []int
+}
+
+// MakeLines 按 cfg 生成以若干条直线为分界的多分类数据
+func MakeLines(src rand.Source, cfg LinesConfig) Lines {
+	if len(cfg.Slopes) != len(cfg.Intercepts) {
+		panic("datasets: 直线的斜率和截距个数不一致")
+	}
+	rnd := rand.New(src)
+	d := Lines{
+		XData:      make([]float64, cfg.Samples),
+		YData:      make([]float64, cfg.Samples),
+		Labels:     make([]int, cfg.Samples),
+		Slopes:     cfg.Slopes,
+		Intercepts: cfg.Intercepts,
+	}
+	for i := 0; i < cfg.Samples; i++ {
+		x := cfg.XMin + rnd.Float64()*(cfg.XMax-cfg.XMin)
+		y := cfg.YMin + rnd.Float64()*(cfg.YMax-cfg.YMin)
+		for j, slope := range cfg.Slopes {
+			if y > slope*x+cfg.Intercepts[j] {
+				d.Labels[i]++
+			}
+		}
+		if cfg.Noise != nil {
+			y += cfg.Noise.Sample(rnd, []float64{x})
+		}
+		d.XData[i], d.YData[i] = x, y
+	}
+	d.Flipped = FlipLabels(rnd, d.Labels, len(cfg.Slopes)+1, cfg.FlipRatio)
+	return d
+}
+
+// addNoise 在点 p 的每个坐标上加噪声
+func addNoise(rnd *rand.Rand, p []float64, noise Noise) {
+	if noise == nil {
+		return
+	}
+	x := append([]float64(nil), p...)
+	for j := range p {
+		p[j] += noise.Sample(rnd, x)
+	}
+}
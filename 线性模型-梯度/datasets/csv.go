@@ -0,0 +1,541 @@
+package datasets
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// HeaderMode 指定 CSV 第一行是否是表头
+type HeaderMode int
+
+const (
+	HeaderAuto HeaderMode = iota // 第一行中有无法解析成数字的字段时视为表头
+	HeaderYes
+	HeaderNo
+)
+
+// ParseHeaderMode 把命令行中的 auto、yes、no 转换成 HeaderMode
+func ParseHeaderMode(s string) (HeaderMode, error) {
+	switch s {
+	case "", "auto":
+		return HeaderAuto, nil
+	case "yes", "true":
+		return HeaderYes, nil
+	case "no", "false":
+		return HeaderNo, nil
+	}
+	return HeaderAuto, fmt.Errorf("datasets: 未知的表头模式 %q，可选：auto, yes, no", s)
+}
+
+// Missing 缺失值的处理方式
+type Missing string
+
+const (
+	MissingSkip   Missing = "skip"   // 丢弃含缺失值的行
+	MissingMean   Missing = "mean"   // 用该列其余值的均值填补
+	MissingMedian Missing = "median" // 用该列其余值的中位数填补
+)
+
+// MissingNames 返回支持的缺失值处理方式
+func MissingNames() []string {
+	return []string{string(MissingSkip), string(MissingMean), string(MissingMedian)}
+}
+
+// DefaultMissingTokens 默认视为缺失值的字段内容（不区分大小写，空字段也视为缺失）
+var DefaultMissingTokens = []string{"", "na", "nan", "null", "none", "?"}
+
+// CSVOptions 读取 CSV/TSV 的选项，零值表示按扩展名选择分隔符、自动检测表头、
+// 除目标列外的全部列作为特征、没有目标列、丢弃含缺失值的行
+type CSVOptions struct {
+	Comma    rune       // 分隔符，0 表示 .tsv/.tab 文件用制表符，其余根据第一行内容判断
+	Header   HeaderMode // 第一行是否是表头
+	Features []string   // 特征列的列名或从 0 开始的列号，为空时使用除目标列外的全部列
+	Target   string     // 目标列的列名或列号，为空时没有目标列（聚类）
+	Missing  Missing    // 特征缺失值的处理方式，为空时同 MissingSkip；目标值缺失的行总是被丢弃
+	Tokens   []string   // 视为缺失值的字段内容，为 nil 时使用 DefaultMissingTokens
+}
+
+// Table 从 CSV 读出或准备导出的数值表
+type Table struct {
+	X            *mat.Dense // 特征矩阵，每行是一个样本
+	Y            []float64  // 目标值，没有目标列时为 nil
+	FeatureNames []string
+	TargetName   string
+	Classes      []string // 目标列是文本时按字典序排列的类别名称，Y 中存放类别下标；数值目标列为 nil
+	Skipped      []int    // 因缺失值被丢弃的行号（从 1 开始，包含表头所在行）
+	Imputed      int      // 被填补的缺失值个数
+}
+
+// LoadCSV 读取 path 指向的 CSV/TSV 文件
+func LoadCSV(path string, opts CSVOptions) (Table, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Table{}, err
+	}
+	defer f.Close()
+
+	if opts.Comma == 0 {
+		opts.Comma = commaForPath(path)
+	}
+	t, err := ReadCSV(f, opts)
+	if err != nil {
+		return Table{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return t, nil
+}
+
+// ReadCSV 从 r 读取 CSV/TSV 数据
+func ReadCSV(r io.Reader, opts CSVOptions) (Table, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return Table{}, err
+	}
+	cr := csv.NewReader(bytes.NewReader(raw))
+	cr.Comma = opts.Comma
+	if cr.Comma == 0 {
+		cr.Comma = sniffComma(raw)
+	}
+	cr.Comment = '#'
+	cr.TrimLeadingSpace = true
+	records, err := cr.ReadAll()
+	if err != nil {
+		return Table{}, fmt.Errorf("datasets: %w", err)
+	}
+	if len(records) == 0 {
+		return Table{}, fmt.Errorf("datasets: 文件为空")
+	}
+
+	tokens := opts.Tokens
+	if tokens == nil {
+		tokens = DefaultMissingTokens
+	}
+	isMissing := func(s string) bool {
+		s = strings.TrimSpace(s)
+		for _, tok := range tokens {
+			if strings.EqualFold(s, tok) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// 表头检测：第一行中只要有一个非缺失字段不是数字，就把它当作表头
+	hasHeader := opts.Header == HeaderYes
+	if opts.Header == HeaderAuto {
+		for _, s := range records[0] {
+			if _, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err != nil && !isMissing(s) {
+				hasHeader = true
+				break
+			}
+		}
+	}
+	names := make([]string, len(records[0]))
+	for j := range names {
+		names[j] = fmt.Sprintf("col%d", j)
+		if hasHeader {
+			names[j] = strings.TrimSpace(records[0][j])
+		}
+	}
+	rows, firstLine := records, 1
+	if hasHeader {
+		rows, firstLine = records[1:], 2
+	}
+
+	target := -1
+	if opts.Target != "" {
+		if target, err = columnIndex(opts.Target, names); err != nil {
+			return Table{}, err
+		}
+	}
+	var features []int
+	if len(opts.Features) == 0 {
+		for j := range names {
+			if j != target {
+				features = append(features, j)
+			}
+		}
+	} else {
+		for _, spec := range opts.Features {
+			j, err := columnIndex(spec, names)
+			if err != nil {
+				return Table{}, err
+			}
+			if j == target {
+				return Table{}, fmt.Errorf("datasets: 列 %q 不能同时作为特征和目标", names[j])
+			}
+			features = append(features, j)
+		}
+	}
+	if len(features) == 0 {
+		return Table{}, fmt.Errorf("datasets: 没有可用的特征列")
+	}
+
+	// 目标列中出现非数字的字段时按类别处理，类别下标按类别名称的字典序分配，
+	// 与行的顺序无关；二分类时排在后面的类别是正类 1
+	var classes []string
+	if target >= 0 {
+		for _, rec := range rows {
+			s := strings.TrimSpace(rec[target])
+			if _, err := strconv.ParseFloat(s, 64); err != nil && !isMissing(s) {
+				classes = []string{}
+				break
+			}
+		}
+	}
+	var targets []string // 文本目标列每个保留样本的类别名称
+
+	missing := opts.Missing
+	if missing == "" {
+		missing = MissingSkip
+	}
+	switch missing {
+	case MissingSkip, MissingMean, MissingMedian:
+	default:
+		return Table{}, fmt.Errorf("datasets: 未知的缺失值处理方式 %q，可选：%v", missing, MissingNames())
+	}
+
+	t := Table{FeatureNames: make([]string, len(features))}
+	for k, j := range features {
+		t.FeatureNames[k] = names[j]
+	}
+	var data []float64
+	for i, rec := range rows {
+		line := firstLine + i
+		if target >= 0 && isMissing(rec[target]) {
+			t.Skipped = append(t.Skipped, line)
+			continue
+		}
+		row := make([]float64, len(features))
+		skip := false
+		for k, j := range features {
+			s := strings.TrimSpace(rec[j])
+			if isMissing(s) {
+				row[k] = math.NaN()
+				skip = skip || missing == MissingSkip
+				continue
+			}
+			v, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return Table{}, fmt.Errorf("datasets: 第 %d 行列 %q 的值 %q 不是数字", line, names[j], s)
+			}
+			row[k] = v
+		}
+		if skip {
+			t.Skipped = append(t.Skipped, line)
+			continue
+		}
+
+		if target >= 0 {
+			s := strings.TrimSpace(rec[target])
+			if classes != nil {
+				targets = append(targets, s)
+			} else {
+				v, _ := strconv.ParseFloat(s, 64)
+				t.Y = append(t.Y, v)
+			}
+		}
+		data = append(data, row...)
+	}
+	if len(data) == 0 {
+		return Table{}, fmt.Errorf("datasets: 丢弃缺失值后没有剩余样本")
+	}
+
+	t.X = mat.NewDense(len(data)/len(features), len(features), data)
+	if classes != nil {
+		classIndex := make(map[string]int)
+		for _, s := range targets {
+			classIndex[s] = 0
+		}
+		for s := range classIndex {
+			classes = append(classes, s)
+		}
+		sort.Strings(classes)
+		for c, s := range classes {
+			classIndex[s] = c
+		}
+		for _, s := range targets {
+			t.Y = append(t.Y, float64(classIndex[s]))
+		}
+	}
+	if target >= 0 {
+		t.TargetName = names[target]
+		t.Classes = classes
+	}
+	if missing != MissingSkip {
+		t.Imputed = impute(t.X, missing)
+	}
+	return t, nil
+}
+
+// impute 用每列非缺失值的均值或中位数原地填补 X 中的 NaN，返回填补的个数
+func impute(X *mat.Dense, missing Missing) int {
+	rows, cols := X.Dims()
+	count := 0
+	for j := 0; j < cols; j++ {
+		var present []float64
+		for i := 0; i < rows; i++ {
+			if v := X.At(i, j); !math.IsNaN(v) {
+				present = append(present, v)
+			}
+		}
+		if len(present) == rows {
+			continue
+		}
+		fill := 0.0
+		if len(present) > 0 {
+			if missing == MissingMedian {
+				sort.Float64s(present)
+				mid := len(present) / 2
+				fill = present[mid]
+				if len(present)%2 == 0 {
+					fill = (present[mid-1] + present[mid]) / 2
+				}
+			} else {
+				for _, v := range present {
+					fill += v
+				}
+				fill /= float64(len(present))
+			}
+		}
+		for i := 0; i < rows; i++ {
+			if math.IsNaN(X.At(i, j)) {
+				X.Set(i, j, fill)
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// columnIndex 按列名查找列，找不到时把 spec 当作从 0 开始的列号
+func columnIndex(spec string, names []string) (int, error) {
+	spec = strings.TrimSpace(spec)
+	for j, name := range names {
+		if name == spec {
+			return j, nil
+		}
+	}
+	if j, err := strconv.Atoi(spec); err == nil && j >= 0 && j < len(names) {
+		return j, nil
+	}
+	return 0, fmt.Errorf("datasets: 找不到列 %q，已有的列：%v", spec, names)
+}
+
+// commaForPath 按扩展名选择分隔符，无法判断时返回 0 交给 sniffComma
+func commaForPath(path string) rune {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".tsv", ".tab":
+		return '\t'
+	case ".csv":
+		return ','
+	}
+	return 0
+}
+
+// sniffComma 根据第一行在制表符、逗号和分号中选择出现次数最多的作为分隔符
+func sniffComma(raw []byte) rune {
+	first, _, _ := bytes.Cut(raw, []byte("\n"))
+	best, bestCount := ',', 0
+	for _, c := range []rune{',', '\t', ';'} {
+		if n := bytes.Count(first, []byte(string(c))); n > bestCount {
+			best, bestCount = c, n
+		}
+	}
+	return best
+}
+
+// SaveCSV 把 t 写入 path，.tsv/.tab 文件使用制表符分隔，其余使用逗号
+func SaveCSV(path string, t Table) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	comma := commaForPath(path)
+	if comma == 0 {
+		comma = ','
+	}
+	if err := WriteCSV(f, t, comma); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// WriteCSV 把 t 以带表头的 CSV 格式写入 w，目标列放在最后一列；
+// 浮点数按最短的精确表示输出，读回后与原值完全相同
+func WriteCSV(w io.Writer, t Table, comma rune) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+
+	header := append([]string(nil), t.FeatureNames...)
+	if t.Y != nil {
+		header = append(header, t.TargetName)
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	rows, cols := t.X.Dims()
+	rec := make([]string, len(header))
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			rec[j] = strconv.FormatFloat(t.X.At(i, j), 'g', -1, 64)
+		}
+		if t.Y != nil {
+			if t.Classes != nil {
+				rec[cols] = t.Classes[int(t.Y[i])]
+			} else {
+				rec[cols] = strconv.FormatFloat(t.Y[i], 'g', -1, 64)
+			}
+		}
+		if err := cw.Write(rec); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Regression 把表转换成回归数据集，真实参数未知，Coef 为 nil
+func (t Table) Regression() (Regression, error) {
+	if t.Y == nil {
+		return Regression{}, fmt.Errorf("datasets: 回归数据需要指定目标列")
+	}
+	if t.Classes != nil {
+		return Regression{}, fmt.Errorf("datasets: 目标列 %q 不是数值", t.TargetName)
+	}
+	return Regression{X: t.X, Y: t.Y}, nil
+}
+
+// Labels 把目标列转换成 0..k-1 的类别标签
+func (t Table) Labels() ([]int, error) {
+	if t.Y == nil {
+		return nil, fmt.Errorf("datasets: 分类数据需要指定目标列")
+	}
+	labels := make([]int, len(t.Y))
+	for i, v := range t.Y {
+		if v < 0 || v != math.Trunc(v) {
+			return nil, fmt.Errorf("datasets: 目标列 %q 的值 %v 不是非负整数类别", t.TargetName, v)
+		}
+		labels[i] = int(v)
+	}
+	return labels, nil
+}
+
+// TakeFeature 取出列名或列号为 spec 的特征列，返回该列的值和去掉该列后的表，
+// 用于把样本权重等不参与训练的列从特征中分离出来；t 本身不变
+func (t Table) TakeFeature(spec string) ([]float64, Table, error) {
+	j, err := columnIndex(spec, t.FeatureNames)
+	if err != nil {
+		return nil, Table{}, err
+	}
+	rows, cols := t.X.Dims()
+	if cols == 1 {
+		return nil, Table{}, fmt.Errorf("datasets: 取出列 %q 后没有剩余的特征列", t.FeatureNames[j])
+	}
+	col := mat.Col(nil, j, t.X)
+	X := mat.NewDense(rows, cols-1, nil)
+	for i := 0; i < rows; i++ {
+		src, dst := t.X.RawRowView(i), X.RawRowView(i)
+		copy(dst, src[:j])
+		copy(dst[j:], src[j+1:])
+	}
+	rest := t
+	rest.X = X
+	rest.FeatureNames = append(append([]string(nil), t.FeatureNames[:j]...), t.FeatureNames[j+1:]...)
+	return col, rest, nil
+}
+
+// Classification 把表转换成分类或聚类数据集，没有目标列时 Labels 为 nil
+func (t Table) Classification() (Classification, error) {
+	c := Classification{X: t.X}
+	if t.Y != nil {
+		labels, err := t.Labels()
+		if err != nil {
+			return Classification{}, err
+		}
+		c.Labels = labels
+	}
+	return c, nil
+}
+
+// Line 把两个特征列和 0/1 目标列转换成 logreg 使用的二维二分类数据，
+// 真实分界线未知，Slope 和 Intercept 为 NaN
+func (t Table) Line() (Line, error) {
+	if _, cols := t.X.Dims(); cols != 2 {
+		return Line{}, fmt.Errorf("datasets: 二维二分类数据需要恰好 2 个特征列，实际为 %d 个", cols)
+	}
+	labels, err := t.Labels()
+	if err != nil {
+		return Line{}, err
+	}
+	for _, l := range labels {
+		if l > 1 {
+			return Line{}, fmt.Errorf("datasets: 目标列 %q 的类别多于 2 个", t.TargetName)
+		}
+	}
+	return Line{
+		XData:     mat.Col(nil, 0, t.X),
+		YData:     mat.Col(nil, 1, t.X),
+		Labels:    labels,
+		Slope:     math.NaN(),
+		Intercept: math.NaN(),
+	}, nil
+}
+
+// Table 把回归数据集转换成可导出的表，单特征时列名为 x、y，多特征时为 x0..xn-1、y
+func (r Regression) Table() Table {
+	return Table{X: r.X, Y: r.Y, FeatureNames: featureNames(r.X), TargetName: "y"}
+}
+
+// Table 把分类数据集转换成可导出的表，特征列名为 x0..xn-1，标签列名为 label
+func (c Classification) Table() Table {
+	t := Table{X: c.X, FeatureNames: featureNames(c.X)}
+	if c.Labels != nil {
+		t.Y = intsToFloats(c.Labels)
+		t.TargetName = "label"
+	}
+	return t
+}
+
+// Table 把以直线为分界的数据集转换成列为 x、y、label 的表
+func (l Line) Table() Table {
+	X := mat.NewDense(len(l.XData), 2, nil)
+	X.SetCol(0, l.XData)
+	X.SetCol(1, l.YData)
+	return Table{X: X, Y: intsToFloats(l.Labels), FeatureNames: []string{"x", "y"}, TargetName: "label"}
+}
+
+// Table 把以若干条直线为分界的数据集转换成列为 x、y、label 的表
+func (l Lines) Table() Table {
+	return Line{XData: l.XData, YData: l.YData, Labels: l.Labels}.Table()
+}
+
+func featureNames(X *mat.Dense) []string {
+	_, cols := X.Dims()
+	if cols == 1 {
+		return []string{"x"}
+	}
+	names := make([]string, cols)
+	for j := range names {
+		names[j] = fmt.Sprintf("x%d", j)
+	}
+	return names
+}
+
+func intsToFloats(v []int) []float64 {
+	f := make([]float64, len(v))
+	for i, x := range v {
+		f[i] = float64(x)
+	}
+	return f
+}
@@ -0,0 +1,87 @@
+// Package datasets 生成用于演示和测试的合成数据集，接口仿照 scikit-learn 的
+// make_regression、make_classification、make_blobs、make_moons 和 make_circles。
+//
+// 所有生成函数都接收一个 golang.org/x/exp/rand.Source（与 gonum distuv 相同），
+// 传入 rng.Source(seed) 即可复现同一份数据。生成结果同时返回真实的系数、
+// 聚类中心、被注入的离群点和被翻转的标签，便于和模型的拟合结果对比。
+package datasets
+
+import (
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Outliers 离群点注入的配置：随机选出 Ratio 比例的样本，把目标值平移 Shift，
+// 再加上 [-Spread, Spread] 内的均匀扰动。Leverage 为真时还把这些样本的第一个特征
+// 移到其取值范围最右侧的四分之一，形成高杠杆点，最小二乘拟合会被明显拉偏
+type Outliers struct {
+	Ratio    float64
+	Shift    float64
+	Spread   float64
+	Leverage bool
+}
+
+// InjectOutliers 按 o 原地修改 X 和 y，返回被替换成离群点的样本下标（升序）
+func InjectOutliers(rnd *rand.Rand, X *mat.Dense, y []float64, o Outliers, xMin, xMax float64) []int {
+	n := len(y)
+	count := int(o.Ratio * float64(n))
+	if count <= 0 {
+		return nil
+	}
+	idx := pick(rnd, n, count)
+	for _, i := range idx {
+		if o.Leverage {
+			X.Set(i, 0, xMax-rnd.Float64()*(xMax-xMin)/4)
+		}
+		y[i] += o.Shift + (2*rnd.Float64()-1)*o.Spread
+	}
+	return idx
+}
+
+// FlipLabels 随机选出 ratio 比例的样本，把标签改成另一个随机类别，返回被翻转的样本下标（升序）
+func FlipLabels(rnd *rand.Rand, labels []int, numClasses int, ratio float64) []int {
+	count := int(ratio * float64(len(labels)))
+	if count <= 0 || numClasses < 2 {
+		return nil
+	}
+	idx := pick(rnd, len(labels), count)
+	for _, i := range idx {
+		// 在其余 numClasses-1 个类别中均匀选一个
+		label := rnd.Intn(numClasses - 1)
+		if label >= labels[i] {
+			label++
+		}
+		labels[i] = label
+	}
+	return idx
+}
+
+// MakeUniform 在 [min, max) 的超立方体内均匀采样 n 个 dims 维的点
+func MakeUniform(src rand.Source, n, dims int, min, max float64) *mat.Dense {
+	rnd := rand.New(src)
+	X := mat.NewDense(n, dims, nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j < dims; j++ {
+			X.Set(i, j, min+rnd.Float64()*(max-min))
+		}
+	}
+	return X
+}
+
+// pick 从 0..n-1 中不重复地随机选出 count 个下标，按升序返回
+func pick(rnd *rand.Rand, n, count int) []int {
+	if count > n {
+		count = n
+	}
+	chosen := make([]bool, n)
+	for _, i := range rnd.Perm(n)[:count] {
+		chosen[i] = true
+	}
+	idx := make([]int, 0, count)
+	for i, ok := range chosen {
+		if ok {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
@@ -0,0 +1,73 @@
+package datasets
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// CSVFlags 各演示程序共用的数据读写参数：-data 指定时从文件读取数据代替合成数据，
+// -export 指定时把本次使用的数据写出，便于分享和复现
+type CSVFlags struct {
+	Path     *string
+	Features *string
+	Target   *string
+	Header   *string
+	Missing  *string
+	Export   *string
+}
+
+// RegisterCSVFlags 在 fs 上注册数据读写参数，target 是目标列的默认值，聚类程序传空字符串
+func RegisterCSVFlags(fs *flag.FlagSet, target string) *CSVFlags {
+	return &CSVFlags{
+		Path:     fs.String("data", "", "从 CSV/TSV 文件读取数据，为空时使用合成数据"),
+		Features: fs.String("features", "", "特征列的列名或列号，用逗号分隔，为空时使用除目标列外的全部列"),
+		Target:   fs.String("target", target, "目标列的列名或列号"),
+		Header:   fs.String("header", "auto", "第一行是否是表头：auto, yes, no"),
+		Missing:  fs.String("missing", string(MissingSkip), fmt.Sprintf("特征缺失值的处理方式，可选：%v", MissingNames())),
+		Export:   fs.String("export", "", "把本次使用的数据写入该 CSV/TSV 文件"),
+	}
+}
+
+// Options 把命令行参数转换成 CSVOptions
+func (f *CSVFlags) Options() (CSVOptions, error) {
+	header, err := ParseHeaderMode(*f.Header)
+	if err != nil {
+		return CSVOptions{}, err
+	}
+	opts := CSVOptions{Header: header, Target: *f.Target, Missing: Missing(*f.Missing)}
+	if *f.Features != "" {
+		opts.Features = strings.Split(*f.Features, ",")
+	}
+	return opts, nil
+}
+
+// Load 在指定了 -data 时读取数据并打印读取情况，未指定时 ok 为 false
+func (f *CSVFlags) Load() (t Table, ok bool, err error) {
+	if *f.Path == "" {
+		return Table{}, false, nil
+	}
+	opts, err := f.Options()
+	if err != nil {
+		return Table{}, false, err
+	}
+	if t, err = LoadCSV(*f.Path, opts); err != nil {
+		return Table{}, false, err
+	}
+	rows, _ := t.X.Dims()
+	fmt.Printf("从 %s 读取 %d 个样本，特征 %v，目标 %q，丢弃 %d 行，填补 %d 个缺失值\n",
+		*f.Path, rows, t.FeatureNames, t.TargetName, len(t.Skipped), t.Imputed)
+	return t, true, nil
+}
+
+// Save 在指定了 -export 时把 t 写出
+func (f *CSVFlags) Save(t Table) error {
+	if *f.Export == "" {
+		return nil
+	}
+	if err := SaveCSV(*f.Export, t); err != nil {
+		return err
+	}
+	fmt.Printf("数据已导出到 %s\n", *f.Export)
+	return nil
+}
@@ -0,0 +1,72 @@
+package datasets
+
+import (
+	"fmt"
+	"math"
+
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// Noise 加在目标值或坐标上的噪声分布
+type Noise interface {
+	// Sample 为特征为 x 的样本采样一个噪声值，异方差噪声会用到 x
+	Sample(rnd *rand.Rand, x []float64) float64
+}
+
+// Gaussian 均值为 0、标准差为 Sigma 的高斯噪声
+type Gaussian struct {
+	Sigma float64
+}
+
+func (g Gaussian) Sample(rnd *rand.Rand, _ []float64) float64 {
+	return rnd.NormFloat64() * g.Sigma
+}
+
+// Uniform 在 [Min, Max) 内均匀分布的噪声
+type Uniform struct {
+	Min, Max float64
+}
+
+func (u Uniform) Sample(rnd *rand.Rand, _ []float64) float64 {
+	return u.Min + rnd.Float64()*(u.Max-u.Min)
+}
+
+// StudentT 自由度为 Nu、尺度为 Scale 的 t 分布噪声，Nu 越小尾部越重，越容易产生极端值
+type StudentT struct {
+	Nu, Scale float64
+}
+
+func (t StudentT) Sample(rnd *rand.Rand, _ []float64) float64 {
+	return distuv.StudentsT{Mu: 0, Sigma: t.Scale, Nu: t.Nu, Src: rnd}.Rand()
+}
+
+// Heteroscedastic 异方差高斯噪声：标准差随第一个特征增大，sigma(x) = Sigma * (1 + Slope*|x0|)
+type Heteroscedastic struct {
+	Sigma, Slope float64
+}
+
+func (h Heteroscedastic) Sample(rnd *rand.Rand, x []float64) float64 {
+	return rnd.NormFloat64() * h.Sigma * (1 + h.Slope*math.Abs(x[0]))
+}
+
+// NoiseNames 返回 NewNoise 支持的噪声名称
+func NoiseNames() []string {
+	return []string{"gaussian", "uniform", "student", "hetero"}
+}
+
+// NewNoise 按名称创建噪声，供命令行选择，scale 是噪声的大致幅度：
+// gaussian 的标准差、uniform 的半宽、student（自由度 3）的尺度、hetero 在 x0=0 处的标准差
+func NewNoise(name string, scale float64) (Noise, error) {
+	switch name {
+	case "gaussian":
+		return Gaussian{Sigma: scale}, nil
+	case "uniform":
+		return Uniform{Min: -scale, Max: scale}, nil
+	case "student":
+		return StudentT{Nu: 3, Scale: scale}, nil
+	case "hetero":
+		return Heteroscedastic{Sigma: scale, Slope: 0.2}, nil
+	}
+	return nil, fmt.Errorf("datasets: 未知的噪声分布 %q，可选：%v", name, NoiseNames())
+}
@@ -0,0 +1,82 @@
+package datasets
+
+import (
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/mat"
+)
+
+// RegressionConfig make_regression 的配置，生成 y = X·Coef + Intercept + noise
+type RegressionConfig struct {
+	Samples    int
+	Features   int
+	Coef       []float64 // 真实权重，为 nil 时在 [-CoefRange, CoefRange] 内随机选取
+	CoefRange  float64
+	Intercept  float64
+	XMin, XMax float64  // 每个特征在 [XMin, XMax) 内均匀采样
+	Noise      Noise    // 加在目标值上的噪声，为 nil 时没有噪声
+	Outliers   Outliers // 离群点注入，零值表示不注入
+}
+
+// Regression 回归数据集及其真实参数
+type Regression struct {
+	X         *mat.Dense // 特征矩阵，每行是一个样本
+	Y         []float64  // 目标值
+	Coef      []float64  // 生成数据所用的真实权重
+	Intercept float64    // 生成数据所用的真实偏置
+	Outliers  []int      // 被替换成离群点的样本下标
+}
+
+// MakeRegression 按 cfg 生成线性回归数据集
+func MakeRegression(src rand.Source, cfg RegressionConfig) Regression {
+	rnd := rand.New(src)
+
+	coef := cfg.Coef
+	if coef == nil {
+		coef = make([]float64, cfg.Features)
+		for j := range coef {
+			coef[j] = (2*rnd.Float64() - 1) * cfg.CoefRange
+		}
+	}
+
+	X := mat.NewDense(cfg.Samples, cfg.Features, nil)
+	y := make([]float64, cfg.Samples)
+	for i := range y {
+		row := X.RawRowView(i)
+		y[i] = cfg.Intercept
+		for j := range row {
+			row[j] = cfg.XMin + rnd.Float64()*(cfg.XMax-cfg.XMin)
+			y[i] += coef[j] * row[j]
+		}
+		if cfg.Noise != nil {
+			y[i] += cfg.Noise.Sample(rnd, row)
+		}
+	}
+
+	outliers := InjectOutliers(rnd, X, y, cfg.Outliers, cfg.XMin, cfg.XMax)
+	return Regression{X: X, Y: y, Coef: coef, Intercept: cfg.Intercept, Outliers: outliers}
+}
+
+// MakeCurve 在 [xMin, xMax) 内均匀采样 n 个 x，生成 y = f(x) + noise 的单特征非线性回归数据
+func MakeCurve(src rand.Source, n int, xMin, xMax float64, f func(float64) float64, noise Noise) Regression {
+	rnd := rand.New(src)
+	X := mat.NewDense(n, 1, nil)
+	y := make([]float64, n)
+	for i := range y {
+		x := xMin + rnd.Float64()*(xMax-xMin)
+		X.Set(i, 0, x)
+		y[i] = f(x)
+		if noise != nil {
+			y[i] += noise.Sample(rnd, []float64{x})
+		}
+	}
+	return Regression{X: X, Y: y}
+}
+
+// Points 把单特征数据集转换成 [x, y] 形式的样本，供 linreg 的单特征函数使用
+func (r Regression) Points() [][]float64 {
+	points := make([][]float64, len(r.Y))
+	for i := range points {
+		points[i] = []float64{r.X.At(i, 0), r.Y[i]}
+	}
+	return points
+}
@@ -0,0 +1,279 @@
+// Package experiment 用 YAML 或 JSON 文件声明一次完整的实验并执行它。
+//
+// 一个实验文件描述数据集的生成方式（或要读取的 CSV 文件）、模型、损失、优化器、
+// 学习率调度、停止判据、要报告的指标和要输出的图像。实验文件可以放进 git 管理，
+// 任何人都能用 ai run <文件> 原样重跑，不需要改动 Go 源码。
+package experiment
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config 一次实验的全部设置，字段名与实验文件中的键一一对应
+type Config struct {
+	Name      string    `json:"name" yaml:"name"`
+	Seed      int64     `json:"seed" yaml:"seed"` // 0 表示按当前时间随机选取，与 -seed 参数相同
+	Dataset   Dataset   `json:"dataset" yaml:"dataset"`
+	Model     Model     `json:"model" yaml:"model"`
+	Loss      Loss      `json:"loss" yaml:"loss"`
+	Optimizer Optimizer `json:"optimizer" yaml:"optimizer"`
+	Schedule  Schedule  `json:"schedule" yaml:"schedule"`
+	Train     Train     `json:"train" yaml:"train"`
+	Stop      Stop      `json:"stop" yaml:"stop"`
+	Split     Split     `json:"split" yaml:"split"`
+	Metrics   []string  `json:"metrics" yaml:"metrics"` // 要报告的指标名称，为空时打印完整报告
+	Plots     []Plot    `json:"plots" yaml:"plots"`
+	Save      string    `json:"save" yaml:"save"` // 把训练好的模型保存到该 JSON 文件，为空时不保存
+}
+
+// Dataset 数据来源：Generator 选择合成数据的生成器，为 csv 时从 CSV.Path 读取
+type Dataset struct {
+	Generator  string      `json:"generator" yaml:"generator"` // regression, line, blobs, classification, moons, circles, csv
+	Samples    int         `json:"samples" yaml:"samples"`
+	Features   int         `json:"features" yaml:"features"` // regression、blobs 和 classification 的特征数
+	Coef       []float64   `json:"coef" yaml:"coef"`         // regression 的真实权重，为空时在 [-coef_range, coef_range] 内随机选取
+	CoefRange  float64     `json:"coef_range" yaml:"coef_range"`
+	Intercept  float64     `json:"intercept" yaml:"intercept"` // regression 的真实偏置或 line 的截距
+	Slope      float64     `json:"slope" yaml:"slope"`         // line 的斜率
+	XMin       float64     `json:"x_min" yaml:"x_min"`
+	XMax       float64     `json:"x_max" yaml:"x_max"`
+	Noise      Noise       `json:"noise" yaml:"noise"`
+	Outliers   Outliers    `json:"outliers" yaml:"outliers"`
+	Flip       float64     `json:"flip" yaml:"flip"`       // 分类数据中随机翻转标签的样本比例
+	Centers    [][]float64 `json:"centers" yaml:"centers"` // blobs 的中心，为空时在 [center_min, center_max) 内随机选取 num_centers 个
+	NumCenters int         `json:"num_centers" yaml:"num_centers"`
+	CenterMin  float64     `json:"center_min" yaml:"center_min"`
+	CenterMax  float64     `json:"center_max" yaml:"center_max"`
+	Classes    int         `json:"classes" yaml:"classes"`     // classification 的类别数
+	ClassSep   float64     `json:"class_sep" yaml:"class_sep"` // classification 类别中心之间的距离
+	Factor     float64     `json:"factor" yaml:"factor"`       // circles 内圆与外圆的半径比
+	CSV        CSV         `json:"csv" yaml:"csv"`
+}
+
+// Noise 噪声分布：设置了 Min 或 Max 时是 [Min, Max) 上的均匀噪声，
+// 否则按 Type（gaussian, uniform, student, hetero）和幅度 Scale 创建，见 datasets.NewNoise
+type Noise struct {
+	Type  string  `json:"type" yaml:"type"`
+	Scale float64 `json:"scale" yaml:"scale"`
+	Min   float64 `json:"min" yaml:"min"`
+	Max   float64 `json:"max" yaml:"max"`
+}
+
+// Outliers regression 的离群点注入，含义同 datasets.Outliers，ratio 为 0 时不注入
+type Outliers struct {
+	Ratio    float64 `json:"ratio" yaml:"ratio"`
+	Shift    float64 `json:"shift" yaml:"shift"`
+	Spread   float64 `json:"spread" yaml:"spread"`
+	Leverage bool    `json:"leverage" yaml:"leverage"`
+}
+
+// CSV 从文件读取数据时的设置，含义与各子命令的 -data、-features 等参数相同
+type CSV struct {
+	Path     string   `json:"path" yaml:"path"` // 相对路径相对于实验文件所在的目录
+	Features []string `json:"features" yaml:"features"`
+	Target   string   `json:"target" yaml:"target"` // 为空时线性回归取 y 列，逻辑回归取 label 列
+	Header   string   `json:"header" yaml:"header"`
+	Missing  string   `json:"missing" yaml:"missing"`
+}
+
+// Model 模型种类、特征缩放和正则化
+type Model struct {
+	Type    string  `json:"type" yaml:"type"`   // linear 或 logistic
+	Scale   string  `json:"scale" yaml:"scale"` // 特征缩放，见 scale.Names，为空时不缩放
+	Penalty Penalty `json:"penalty" yaml:"penalty"`
+}
+
+// Penalty 正则化，Type 为空时不正则化
+type Penalty struct {
+	Type              string   `json:"type" yaml:"type"` // ridge, lasso, elasticnet
+	Alpha             float64  `json:"alpha" yaml:"alpha"`
+	L1Ratio           *float64 `json:"l1_ratio" yaml:"l1_ratio"` // elasticnet 中 L1 部分所占比例，未给出时为 0.5，可以显式写 0
+	PenalizeIntercept bool     `json:"penalize_intercept" yaml:"penalize_intercept"`
+}
+
+// l1Ratio 返回 L1Ratio 的值，未给出时为 0
+func (p Penalty) l1Ratio() float64 {
+	if p.L1Ratio == nil {
+		return 0
+	}
+	return *p.L1Ratio
+}
+
+// Loss 线性回归的损失函数，见 loss.New；逻辑回归总是使用交叉熵
+type Loss struct {
+	Name  string  `json:"name" yaml:"name"`
+	Param float64 `json:"param" yaml:"param"`
+}
+
+// Optimizer 参数更新规则，见 optim.New
+type Optimizer struct {
+	Name string  `json:"name" yaml:"name"`
+	LR   float64 `json:"lr" yaml:"lr"`
+}
+
+// Schedule 学习率调度，见 optim.NewSchedule
+type Schedule struct {
+	Name   string `json:"name" yaml:"name"`
+	Warmup int    `json:"warmup" yaml:"warmup"`
+}
+
+// Train 训练轮数、批量大小等，含义同 train.Config
+type Train struct {
+	Epochs   int `json:"epochs" yaml:"epochs"`
+	Batch    int `json:"batch" yaml:"batch"`
+	LogEvery int `json:"log_every" yaml:"log_every"`
+	Workers  int `json:"workers" yaml:"workers"`
+}
+
+// Stop 提前停止的判据，含义同 train.StopRule
+type Stop struct {
+	LossTol  float64 `json:"loss_tol" yaml:"loss_tol"`
+	GradTol  float64 `json:"grad_tol" yaml:"grad_tol"`
+	ParamTol float64 `json:"param_tol" yaml:"param_tol"`
+	Patience int     `json:"patience" yaml:"patience"`
+}
+
+// Split 留出测试集和交叉验证；逻辑回归按类别分层划分
+type Split struct {
+	TestRatio float64 `json:"test_ratio" yaml:"test_ratio"` // 留作测试集的样本比例，0 表示在全部样本上训练和评估
+	CV        string  `json:"cv" yaml:"cv"`                 // 交叉验证方式，见 cv.Methods
+	Folds     int     `json:"folds" yaml:"folds"`           // 在训练集上交叉验证的折数，<2 时不做交叉验证
+}
+
+// Plot 一张输出图像
+type Plot struct {
+	Type string `json:"type" yaml:"type"` // loss（训练损失曲线）、fit（单特征回归的拟合直线）、boundary（两个特征的分类边界）
+	File string `json:"file" yaml:"file"`
+}
+
+// Load 读取实验文件，按扩展名 .yaml/.yml 或 .json 解析；文件中出现未知的键时报错，
+// 避免拼错的设置被静默忽略。CSV 的相对路径被改写成相对于实验文件所在目录的路径
+func Load(path string) (Config, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+	var cfg Config
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(raw))
+		dec.KnownFields(true)
+		err = dec.Decode(&cfg)
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&cfg)
+	default:
+		return Config{}, fmt.Errorf("experiment: 无法识别 %s 的格式，扩展名应为 .yaml、.yml 或 .json", path)
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("experiment: 解析 %s: %v", path, err)
+	}
+	if cfg.Name == "" {
+		cfg.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if p := cfg.Dataset.CSV.Path; p != "" && !filepath.IsAbs(p) {
+		cfg.Dataset.CSV.Path = filepath.Join(filepath.Dir(path), p)
+	}
+	cfg.setDefaults()
+	return cfg, cfg.Validate()
+}
+
+// setDefaults 为没有在文件中给出的设置填上与各子命令相同的默认值
+func (c *Config) setDefaults() {
+	if c.Dataset.Samples == 0 {
+		c.Dataset.Samples = 1000
+	}
+	if c.Dataset.Noise.Type == "" {
+		c.Dataset.Noise.Type = "gaussian"
+	}
+	if c.Dataset.CSV.Target == "" {
+		c.Dataset.CSV.Target = "y"
+		if c.Model.Type == "logistic" {
+			c.Dataset.CSV.Target = "label"
+		}
+	}
+	if c.Model.Penalty.Type == "elasticnet" && c.Model.Penalty.L1Ratio == nil {
+		r := 0.5
+		c.Model.Penalty.L1Ratio = &r
+	}
+	if c.Loss.Name == "" {
+		c.Loss.Name = "mse"
+	}
+	if c.Optimizer.Name == "" {
+		c.Optimizer.Name = "sgd"
+	}
+	if c.Optimizer.LR == 0 {
+		c.Optimizer.LR = 0.01
+	}
+	if c.Schedule.Name == "" {
+		c.Schedule.Name = "constant"
+	}
+	if c.Train.Epochs == 0 {
+		c.Train.Epochs = 1000
+	}
+	if c.Split.CV == "" {
+		c.Split.CV = "kfold"
+		if c.Model.Type == "logistic" {
+			c.Split.CV = "stratified"
+		}
+	}
+}
+
+// Validate 检查各项设置的取值，名称类设置在真正创建对象时再检查
+func (c Config) Validate() error {
+	switch c.Model.Type {
+	case "linear", "logistic":
+	default:
+		return fmt.Errorf("experiment: 未知的模型 %q，可选：linear, logistic", c.Model.Type)
+	}
+	if c.Dataset.Generator == "" {
+		return fmt.Errorf("experiment: 没有指定 dataset.generator")
+	}
+	if c.Dataset.Generator == "csv" && c.Dataset.CSV.Path == "" {
+		return fmt.Errorf("experiment: dataset.generator 为 csv 时需要指定 dataset.csv.path")
+	}
+	if c.Split.TestRatio < 0 || c.Split.TestRatio >= 1 {
+		return fmt.Errorf("experiment: split.test_ratio 应在 [0, 1) 内，实际为 %g", c.Split.TestRatio)
+	}
+	if c.Train.Epochs < 1 {
+		return fmt.Errorf("experiment: train.epochs 至少为 1，实际为 %d", c.Train.Epochs)
+	}
+	if c.Split.Folds < 0 {
+		return fmt.Errorf("experiment: split.folds 不能为负数，实际为 %d", c.Split.Folds)
+	}
+	// 交叉验证只在训练集上进行，合成数据的训练集大小可以预先算出；CSV 文件的样本数要读取后才知道
+	if c.Split.Folds >= 2 && c.Split.CV != "loo" && c.Dataset.Generator != "csv" {
+		if n := c.Dataset.Samples - int(math.Round(c.Split.TestRatio*float64(c.Dataset.Samples))); c.Split.Folds > n {
+			return fmt.Errorf("experiment: split.folds 为 %d，但训练集只有 %d 个样本", c.Split.Folds, n)
+		}
+	}
+	for _, name := range c.Metrics {
+		if !slices.Contains(MetricNames(c.Model.Type), name) {
+			return fmt.Errorf("experiment: %s 模型没有指标 %q，可选：%v", c.Model.Type, name, MetricNames(c.Model.Type))
+		}
+	}
+	for _, p := range c.Plots {
+		switch p.Type {
+		case "loss", "fit", "boundary":
+		default:
+			return fmt.Errorf("experiment: 未知的图像 %q，可选：loss, fit, boundary", p.Type)
+		}
+		if p.Type == "fit" && c.Model.Type != "linear" || p.Type == "boundary" && c.Model.Type != "logistic" {
+			return fmt.Errorf("experiment: %s 图不适用于 %s 模型", p.Type, c.Model.Type)
+		}
+		if p.File == "" {
+			return fmt.Errorf("experiment: %s 图像没有指定 file", p.Type)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package experiment
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeConfig 把 content 写到临时目录下的 name 文件，返回路径
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadDefaults(t *testing.T) {
+	path := writeConfig(t, "tiny.yaml", `
+dataset:
+  generator: csv
+  csv:
+    path: data/points.csv
+model:
+  type: logistic
+  penalty:
+    type: elasticnet
+`)
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		got, want interface{}
+	}{
+		{"name", cfg.Name, "tiny"},
+		{"dataset.samples", cfg.Dataset.Samples, 1000},
+		{"dataset.noise.type", cfg.Dataset.Noise.Type, "gaussian"},
+		{"dataset.csv.path", cfg.Dataset.CSV.Path, filepath.Join(filepath.Dir(path), "data", "points.csv")},
+		{"dataset.csv.target", cfg.Dataset.CSV.Target, "label"},
+		{"loss.name", cfg.Loss.Name, "mse"},
+		{"optimizer.name", cfg.Optimizer.Name, "sgd"},
+		{"optimizer.lr", cfg.Optimizer.LR, 0.01},
+		{"schedule.name", cfg.Schedule.Name, "constant"},
+		{"train.epochs", cfg.Train.Epochs, 1000},
+		{"split.cv", cfg.Split.CV, "stratified"},
+		{"model.penalty.l1_ratio", cfg.Model.Penalty.l1Ratio(), 0.5},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s 为 %v，应为 %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestLoadL1Ratio(t *testing.T) {
+	tests := []struct {
+		penalty string
+		want    float64
+		set     bool
+	}{
+		{`{"type": "elasticnet", "l1_ratio": 0}`, 0, true},
+		{`{"type": "elasticnet", "l1_ratio": 0.2}`, 0.2, true},
+		{`{"type": "elasticnet"}`, 0.5, true},
+		{`{"type": "ridge"}`, 0, false},
+	}
+	for _, tt := range tests {
+		path := writeConfig(t, "penalty.json", `{"dataset": {"generator": "regression"}, "model": {"type": "linear", "penalty": `+tt.penalty+`}}`)
+		cfg, err := Load(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := cfg.Model.Penalty; (got.L1Ratio != nil) != tt.set || got.l1Ratio() != tt.want {
+			t.Errorf("%s: l1_ratio 为 %v（设置：%v），应为 %v（设置：%v）", tt.penalty, got.l1Ratio(), got.L1Ratio != nil, tt.want, tt.set)
+		}
+	}
+}
+
+func TestLoadRejects(t *testing.T) {
+	tests := []struct {
+		name, file, content, want string
+	}{
+		{"yaml unknown key", "typo.yaml", "dataset:\n  generator: regression\nmodel:\n  type: linear\ntrain:\n  epoch: 10\n", "epoch"},
+		{"json unknown key", "typo.json", `{"dataset": {"generator": "regression"}, "model": {"type": "linear"}, "optimiser": {}}`, "optimiser"},
+		{"extension", "exp.toml", "", "扩展名"},
+		{"epochs", "epochs.json", `{"dataset": {"generator": "regression"}, "model": {"type": "linear"}, "train": {"epochs": -1}}`, "train.epochs"},
+		{"negative folds", "folds.json", `{"dataset": {"generator": "regression"}, "model": {"type": "linear"}, "split": {"folds": -2}}`, "split.folds"},
+		// 100 个样本留出 20% 后训练集只有 80 个样本
+		{"too many folds", "folds.json", `{"dataset": {"generator": "regression", "samples": 100}, "model": {"type": "linear"}, "split": {"test_ratio": 0.2, "folds": 81}}`, "训练集只有 80 个样本"},
+		{"metric", "metric.json", `{"dataset": {"generator": "line"}, "model": {"type": "logistic"}, "metrics": ["r2"]}`, "没有指标"},
+		{"model", "model.json", `{"dataset": {"generator": "line"}, "model": {"type": "svm"}}`, "未知的模型"},
+	}
+	for _, tt := range tests {
+		_, err := Load(writeConfig(t, tt.file, tt.content))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: Load 返回 %v，应包含 %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestExamples(t *testing.T) {
+	paths, err := filepath.Glob("../experiments/*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Fatal("没有找到 experiments 目录下的示例")
+	}
+	for _, path := range paths {
+		if _, err := Load(path); err != nil {
+			t.Errorf("%s: %v", path, err)
+		}
+	}
+}
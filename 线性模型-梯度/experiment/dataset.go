@@ -0,0 +1,105 @@
+package experiment
+
+import (
+	"fmt"
+	"math"
+
+	"ai/datasets"
+	"ai/rng"
+)
+
+// noise 按配置创建噪声分布，没有设置任何幅度时返回 nil（不加噪声）
+func (n Noise) noise() (datasets.Noise, error) {
+	if n.Min != 0 || n.Max != 0 {
+		return datasets.Uniform{Min: n.Min, Max: n.Max}, nil
+	}
+	if n.Scale == 0 {
+		return nil, nil
+	}
+	return datasets.NewNoise(n.Type, n.Scale)
+}
+
+// load 生成或读取数据集，返回数据表和以直线为分界的数据的真实斜率、截距（其余数据为 NaN）
+func (d Dataset) load(seed int64) (t datasets.Table, slope, intercept float64, err error) {
+	slope, intercept = math.NaN(), math.NaN()
+	noise, err := d.Noise.noise()
+	if err != nil {
+		return datasets.Table{}, slope, intercept, err
+	}
+	src := rng.Source(seed)
+
+	switch d.Generator {
+	case "regression":
+		t = datasets.MakeRegression(src, datasets.RegressionConfig{
+			Samples:   d.Samples,
+			Features:  max(d.Features, len(d.Coef), 1),
+			Coef:      d.Coef,
+			CoefRange: d.CoefRange,
+			Intercept: d.Intercept,
+			XMin:      d.XMin,
+			XMax:      d.XMax,
+			Noise:     noise,
+			Outliers:  datasets.Outliers(d.Outliers),
+		}).Table()
+	case "line":
+		line := datasets.MakeLine(src, datasets.LineConfig{
+			Samples:   d.Samples,
+			Slope:     d.Slope,
+			Intercept: d.Intercept,
+			XMin:      d.XMin,
+			XMax:      d.XMax,
+			Noise:     noise,
+			FlipRatio: d.Flip,
+		})
+		t, slope, intercept = line.Table(), line.Slope, line.Intercept
+	case "blobs":
+		t = datasets.MakeBlobs(src, datasets.BlobsConfig{
+			Samples:    d.Samples,
+			Centers:    d.Centers,
+			NumCenters: max(d.NumCenters, 2),
+			Features:   max(d.Features, 2),
+			CenterMin:  d.CenterMin,
+			CenterMax:  d.CenterMax,
+			Noise:      noise,
+			FlipRatio:  d.Flip,
+		}).Table()
+	case "classification":
+		t = datasets.MakeClassification(src, datasets.ClassificationConfig{
+			Samples:   d.Samples,
+			Features:  max(d.Features, 2),
+			Classes:   max(d.Classes, 2),
+			ClassSep:  d.ClassSep,
+			Noise:     noise,
+			FlipRatio: d.Flip,
+		}).Table()
+	case "moons":
+		t = datasets.MakeMoons(src, d.Samples, noise).Table()
+	case "circles":
+		t = datasets.MakeCircles(src, d.Samples, d.Factor, noise).Table()
+	case "csv":
+		header, err := datasets.ParseHeaderMode(d.CSV.Header)
+		if err != nil {
+			return datasets.Table{}, slope, intercept, err
+		}
+		t, err = datasets.LoadCSV(d.CSV.Path, datasets.CSVOptions{
+			Header:   header,
+			Features: d.CSV.Features,
+			Target:   d.CSV.Target,
+			Missing:  datasets.Missing(d.CSV.Missing),
+		})
+		if err != nil {
+			return datasets.Table{}, slope, intercept, err
+		}
+		rows, _ := t.X.Dims()
+		fmt.Printf("从 %s 读取 %d 个样本，特征 %v，目标 %q，丢弃 %d 行，填补 %d 个缺失值\n",
+			d.CSV.Path, rows, t.FeatureNames, t.TargetName, len(t.Skipped), t.Imputed)
+	default:
+		return datasets.Table{}, slope, intercept, fmt.Errorf("experiment: 未知的数据生成器 %q，可选：%v", d.Generator, Generators())
+	}
+	return t, slope, intercept, nil
+}
+
+// Generators 返回 dataset.generator 支持的全部取值
+func Generators() []string {
+	return []string{"regression", "line", "blobs", "classification", "moons", "circles", "csv"}
+}
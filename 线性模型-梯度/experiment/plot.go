@@ -0,0 +1,161 @@
+package experiment
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// plot 按 p.Type 画一张图并保存到 p.File，数据点使用全部样本
+func (c Config) plot(p Plot, f fitted, d data, res Result) error {
+	var (
+		pl  *plot.Plot
+		err error
+	)
+	switch p.Type {
+	case "loss":
+		pl, err = lossPlot(f)
+	case "fit":
+		pl, err = fitPlot(f, d, res)
+	case "boundary":
+		pl, err = boundaryPlot(f, d, res)
+	}
+	if err != nil {
+		return err
+	}
+	pl.Title.Text = c.Name + ": " + pl.Title.Text
+	return pl.Save(10*vg.Inch, 8*vg.Inch, p.File)
+}
+
+// lossPlot 画出每一轮结束时全部训练样本上的损失
+func lossPlot(f fitted) (*plot.Plot, error) {
+	p := plot.New()
+	p.Title.Text = "Training Loss"
+	p.X.Label.Text = "Epoch"
+	p.Y.Label.Text = "Loss"
+
+	pts := make(plotter.XYs, f.hist.Epochs())
+	for i, l := range f.hist.Loss {
+		pts[i] = plotter.XY{X: float64(i), Y: l}
+	}
+	line, err := plotter.NewLine(pts)
+	if err != nil {
+		return nil, err
+	}
+	line.Color = color.RGBA{R: 0, G: 0, B: 255, A: 255}
+	p.Add(line)
+	return p, nil
+}
+
+// fitPlot 画出单特征线性回归的样本和拟合直线
+func fitPlot(f fitted, d data, res Result) (*plot.Plot, error) {
+	if d.labels != nil || len(f.w) != 1 {
+		return nil, fmt.Errorf("experiment: fit 图只适用于单特征的线性回归")
+	}
+	p := plot.New()
+	p.Title.Text = "Fitted Line (" + res.Regression.Summary() + ")"
+	p.X.Label.Text = d.table.FeatureNames[0]
+	p.Y.Label.Text = d.table.TargetName
+
+	x := mat.Col(nil, 0, d.table.X)
+	pts := make(plotter.XYs, len(x))
+	for i := range x {
+		pts[i] = plotter.XY{X: x[i], Y: d.table.Y[i]}
+	}
+	scatter, err := plotter.NewScatter(pts)
+	if err != nil {
+		return nil, err
+	}
+	scatter.Color = color.RGBA{R: 255, G: 0, B: 0, A: 255}
+	scatter.Radius = vg.Points(2)
+	p.Add(scatter)
+
+	x1, x2 := floats.Min(x), floats.Max(x)
+	line, err := plotter.NewLine(plotter.XYs{
+		{X: x1, Y: f.b + f.w[0]*x1},
+		{X: x2, Y: f.b + f.w[0]*x2},
+	})
+	if err != nil {
+		return nil, err
+	}
+	line.Color = color.RGBA{R: 0, G: 0, B: 255, A: 255}
+	line.Width = vg.Points(2)
+	p.Add(line)
+	p.Legend.Add(fmt.Sprintf("Fitted Line (y=%.4gx+%.4g)", f.w[0], f.b), line)
+	return p, nil
+}
+
+// boundaryPlot 画出两个特征的样本、真实分界线（已知时）和分类边界 b + w0*x + w1*y = 0
+func boundaryPlot(f fitted, d data, res Result) (*plot.Plot, error) {
+	if d.labels == nil || len(f.w) != 2 {
+		return nil, fmt.Errorf("experiment: boundary 图只适用于两个特征的逻辑回归")
+	}
+	p := plot.New()
+	p.Title.Text = "Decision Boundary (" + res.Classification.Summary() + ")"
+	p.X.Label.Text = d.table.FeatureNames[0]
+	p.Y.Label.Text = d.table.FeatureNames[1]
+
+	var pos, neg plotter.XYs
+	for i, l := range d.labels {
+		pt := plotter.XY{X: d.table.X.At(i, 0), Y: d.table.X.At(i, 1)}
+		if l == 1 {
+			pos = append(pos, pt)
+		} else {
+			neg = append(neg, pt)
+		}
+	}
+	for _, s := range []struct {
+		pts   plotter.XYs
+		color color.RGBA
+		name  string
+	}{
+		{pos, color.RGBA{R: 255, G: 0, B: 0, A: 255}, "Class 1"},
+		{neg, color.RGBA{R: 0, G: 0, B: 255, A: 255}, "Class 0"},
+	} {
+		scatter, err := plotter.NewScatter(s.pts)
+		if err != nil {
+			return nil, err
+		}
+		scatter.Color = s.color
+		scatter.Radius = vg.Points(3)
+		p.Add(scatter)
+		p.Legend.Add(s.name, scatter)
+	}
+
+	x := mat.Col(nil, 0, d.table.X)
+	x1, x2 := floats.Min(x), floats.Max(x)
+	if !math.IsNaN(d.slope) {
+		trueLine, err := plotter.NewLine(plotter.XYs{
+			{X: x1, Y: d.slope*x1 + d.intercept},
+			{X: x2, Y: d.slope*x2 + d.intercept},
+		})
+		if err != nil {
+			return nil, err
+		}
+		trueLine.Color = color.RGBA{R: 0, G: 0, B: 0, A: 255}
+		trueLine.Width = vg.Points(2)
+		trueLine.Dashes = []vg.Length{vg.Points(5), vg.Points(5)}
+		p.Add(trueLine)
+		p.Legend.Add(fmt.Sprintf("True Line (y=%gx+%g)", d.slope, d.intercept), trueLine)
+	}
+	if f.w[1] != 0 {
+		fitLine, err := plotter.NewLine(plotter.XYs{
+			{X: x1, Y: (-f.b - f.w[0]*x1) / f.w[1]},
+			{X: x2, Y: (-f.b - f.w[0]*x2) / f.w[1]},
+		})
+		if err != nil {
+			return nil, err
+		}
+		fitLine.Color = color.RGBA{R: 0, G: 255, B: 0, A: 255}
+		fitLine.Width = vg.Points(2)
+		p.Add(fitLine)
+		p.Legend.Add("Fitted Boundary", fitLine)
+	}
+	return p, nil
+}
@@ -0,0 +1,87 @@
+package experiment
+
+import (
+	"fmt"
+	"strings"
+
+	"ai/metrics"
+)
+
+// regressionMetrics 线性回归可以报告的指标，按打印顺序排列
+var regressionMetrics = []struct {
+	Name  string
+	Value func(r metrics.RegressionReport) float64
+}{
+	{"r2", func(r metrics.RegressionReport) float64 { return r.R2 }},
+	{"adjusted_r2", func(r metrics.RegressionReport) float64 { return r.AdjustedR2 }},
+	{"mae", func(r metrics.RegressionReport) float64 { return r.MAE }},
+	{"rmse", func(r metrics.RegressionReport) float64 { return r.RMSE }},
+	{"mape", func(r metrics.RegressionReport) float64 { return r.MAPE }},
+	{"explained_variance", func(r metrics.RegressionReport) float64 { return r.ExplainedVariance }},
+}
+
+// classificationMetrics 逻辑回归可以报告的指标，按打印顺序排列
+var classificationMetrics = []struct {
+	Name  string
+	Value func(r metrics.ClassificationReport) float64
+}{
+	{"accuracy", func(r metrics.ClassificationReport) float64 { return r.Accuracy }},
+	{"precision", func(r metrics.ClassificationReport) float64 { return r.Precision }},
+	{"recall", func(r metrics.ClassificationReport) float64 { return r.Recall }},
+	{"f1", func(r metrics.ClassificationReport) float64 { return r.F1 }},
+	{"balanced_accuracy", func(r metrics.ClassificationReport) float64 { return r.BalancedAccuracy }},
+	{"log_loss", func(r metrics.ClassificationReport) float64 { return r.LogLoss }},
+	{"roc_auc", func(r metrics.ClassificationReport) float64 { return r.ROCAUC }},
+	{"pr_auc", func(r metrics.ClassificationReport) float64 { return r.PRAUC }},
+}
+
+// MetricNames 返回模型 modelType 可以报告的指标名称
+func MetricNames(modelType string) []string {
+	var names []string
+	if modelType == "logistic" {
+		for _, m := range classificationMetrics {
+			names = append(names, m.Name)
+		}
+	} else {
+		for _, m := range regressionMetrics {
+			names = append(names, m.Name)
+		}
+	}
+	return names
+}
+
+// formatRegression 按 selected 中的顺序列出回归指标，selected 为空时返回完整报告
+func formatRegression(r metrics.RegressionReport, selected []string) string {
+	if len(selected) == 0 {
+		return r.String()
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%-20s %12s\n", "metric", "value")
+	for _, name := range selected {
+		for _, m := range regressionMetrics {
+			if m.Name == name {
+				fmt.Fprintf(&sb, "%-20s %12.6g\n", name, m.Value(r))
+			}
+		}
+	}
+	fmt.Fprintf(&sb, "%-20s %12d\n", "samples", r.N)
+	return sb.String()
+}
+
+// formatClassification 按 selected 中的顺序列出分类指标，selected 为空时返回完整报告
+func formatClassification(r metrics.ClassificationReport, selected []string) string {
+	if len(selected) == 0 {
+		return r.String()
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%-20s %12s\n", "metric", "value")
+	for _, name := range selected {
+		for _, m := range classificationMetrics {
+			if m.Name == name {
+				fmt.Fprintf(&sb, "%-20s %12.6g\n", name, m.Value(r))
+			}
+		}
+	}
+	fmt.Fprintf(&sb, "%-20s %12d\n", "samples", r.N)
+	return sb.String()
+}
@@ -0,0 +1,271 @@
+package experiment
+
+import (
+	"fmt"
+
+	"ai/cv"
+	"ai/datasets"
+	"ai/linreg"
+	"ai/logreg"
+	"ai/loss"
+	"ai/metrics"
+	"ai/model"
+	"ai/optim"
+	"ai/rng"
+	"ai/scale"
+	"ai/train"
+)
+
+// Result 一次实验的结果，参数都是原始单位
+type Result struct {
+	Intercept      float64
+	Coef           []float64
+	History        train.History
+	Regression     metrics.RegressionReport     // 线性回归在测试集上的指标
+	Classification metrics.ClassificationReport // 逻辑回归在测试集上的指标
+	CVScores       cv.Scores                    // 交叉验证每折的 R² 或准确率，没有做交叉验证时为 nil
+}
+
+// data 一次实验使用的数据
+type data struct {
+	table            datasets.Table
+	labels           []int   // 逻辑回归的 0/1 标签，线性回归为 nil
+	slope, intercept float64 // 以直线为分界的数据的真实分界线，未知时为 NaN
+}
+
+// fitted 训练好的模型，参数是原始单位
+type fitted struct {
+	b      float64
+	w      []float64
+	hist   train.History
+	scaler scale.Scaler
+}
+
+// Run 按 cfg 生成数据、训练、评估、画图并（可选）保存模型，进度和结果打印到标准输出
+func Run(cfg Config) (Result, error) {
+	if err := cfg.Validate(); err != nil {
+		return Result{}, err
+	}
+	fmt.Printf("实验：%s\n", cfg.Name)
+	seed := rng.Setup(cfg.Seed)
+
+	d, err := cfg.data(seed)
+	if err != nil {
+		return Result{}, err
+	}
+	n, _ := d.table.X.Dims()
+
+	// 划分训练集和测试集，test_ratio 为 0 时在全部样本上训练和评估
+	split := cv.Split{Train: train.All(n), Test: train.All(n)}
+	if cfg.Split.TestRatio > 0 {
+		if d.labels != nil {
+			split = cv.StratifiedTrainTestSplit(rng.New(seed), d.labels, cfg.Split.TestRatio)
+		} else {
+			split = cv.TrainTestSplit(rng.New(seed), n, cfg.Split.TestRatio)
+		}
+		fmt.Printf("训练集 %d 个样本，测试集 %d 个样本\n", len(split.Train), len(split.Test))
+	}
+
+	var res Result
+	trainData, testData := d.subset(split.Train), d.subset(split.Test)
+
+	// 交叉验证：只在训练集上划分，测试集不参与，每折从零开始训练，报告测试折 R² 或准确率的均值 ± 标准差
+	if cfg.Split.Folds >= 2 {
+		splits, err := cv.Splits(cfg.Split.CV, rng.New(seed), len(split.Train), trainData.labels, cfg.Split.Folds)
+		if err != nil {
+			return Result{}, err
+		}
+		var foldErr error
+		res.CVScores = cv.CrossValidate(splits, func(s cv.Split) float64 {
+			f, err := cfg.fit(trainData.subset(s.Train), seed, false)
+			if err != nil {
+				foldErr = err
+				return 0
+			}
+			return cfg.score(f, trainData.subset(s.Test))
+		})
+		if foldErr != nil {
+			return Result{}, foldErr
+		}
+		fmt.Printf("%s 交叉验证%s：%v\n", cfg.Split.CV, cfg.scoreName(), res.CVScores)
+	}
+
+	f, err := cfg.fit(trainData, seed, true)
+	if err != nil {
+		return Result{}, err
+	}
+	res.Intercept, res.Coef, res.History = f.b, f.w, f.hist
+	last := f.hist.Epochs() - 1
+	fmt.Printf("共训练 %d 轮，最终损失: %.6g，梯度范数: %.6g\n", f.hist.Epochs(), f.hist.Loss[last], f.hist.GradNorm[last])
+	if f.hist.StopReason != "" {
+		fmt.Printf("提前停止：%s\n", f.hist.StopReason)
+	}
+	fmt.Printf("训练后参数：b=%.6g, w=%.6g\n", f.b, f.w)
+
+	// 在测试集上计算指标
+	fmt.Println()
+	if d.labels != nil {
+		res.Classification = metrics.EvaluateBinary(testData.labels, logreg.PredictProba(f.b, f.w, testData.table.X), 0.5)
+		fmt.Print(formatClassification(res.Classification, cfg.Metrics))
+	} else {
+		res.Regression = metrics.EvaluateRegression(testData.table.Y, linreg.Predict(f.b, f.w, testData.table.X), len(f.w))
+		fmt.Print(formatRegression(res.Regression, cfg.Metrics))
+	}
+
+	for _, p := range cfg.Plots {
+		if err := cfg.plot(p, f, d, res); err != nil {
+			return Result{}, err
+		}
+		fmt.Printf("图像已保存为 %s\n", p.File)
+	}
+
+	// 与各子命令的 -save 相同，保存缩放后特征空间中的参数和缩放器状态
+	if cfg.Save != "" {
+		if err := model.Save(cfg.Save, cfg.model(f, trainData.table, seed)); err != nil {
+			return Result{}, err
+		}
+		fmt.Printf("模型已保存到 %s\n", cfg.Save)
+	}
+	return res, nil
+}
+
+// data 生成或读取数据集，逻辑回归还要把目标列转换成 0/1 标签
+func (c Config) data(seed int64) (data, error) {
+	t, slope, intercept, err := c.Dataset.load(seed)
+	if err != nil {
+		return data{}, err
+	}
+	if t.Y == nil {
+		return data{}, fmt.Errorf("experiment: 数据没有目标列")
+	}
+	d := data{table: t, slope: slope, intercept: intercept}
+	if c.Model.Type == "logistic" {
+		if d.labels, err = t.Labels(); err != nil {
+			return data{}, err
+		}
+		for _, l := range d.labels {
+			if l > 1 {
+				return data{}, fmt.Errorf("experiment: 逻辑回归只支持二分类，目标列 %q 的类别多于 2 个", t.TargetName)
+			}
+		}
+	} else if t.Classes != nil {
+		return data{}, fmt.Errorf("experiment: 目标列 %q 不是数值", t.TargetName)
+	}
+	return d, nil
+}
+
+// subset 取出 idx 指定的样本
+func (d data) subset(idx []int) data {
+	s := d
+	s.table.X = cv.Rows(d.table.X, idx)
+	s.table.Y = cv.Floats(d.table.Y, idx)
+	if d.labels != nil {
+		s.labels = cv.Ints(d.labels, idx)
+	}
+	return s
+}
+
+// trainConfig 按实验设置为 n 个样本的训练创建 train.Config；
+// 优化器带有状态，每次训练都重新创建
+func (c Config) trainConfig(n int, seed int64, verbose bool) (train.Config, error) {
+	opt, err := optim.New(c.Optimizer.Name)
+	if err != nil {
+		return train.Config{}, err
+	}
+	penalty, err := train.NewPenalty(c.Model.Penalty.Type, c.Model.Penalty.Alpha, c.Model.Penalty.l1Ratio())
+	if err != nil {
+		return train.Config{}, err
+	}
+	penalty.PenalizeIntercept = c.Model.Penalty.PenalizeIntercept
+	cfg := train.Config{
+		LR:        c.Optimizer.LR,
+		Epochs:    c.Train.Epochs,
+		BatchSize: c.Train.Batch,
+		Seed:      seed,
+		Optimizer: opt,
+		Penalty:   penalty,
+		Workers:   c.Train.Workers,
+		Stop: train.StopRule{
+			LossTol:  c.Stop.LossTol,
+			GradTol:  c.Stop.GradTol,
+			ParamTol: c.Stop.ParamTol,
+			Patience: c.Stop.Patience,
+		},
+	}
+	if verbose {
+		cfg.LogEvery = c.Train.LogEvery
+	}
+	cfg.Schedule, err = optim.NewSchedule(c.Schedule.Name, c.Optimizer.LR, cfg.TotalSteps(n), c.Schedule.Warmup)
+	if err != nil {
+		return train.Config{}, err
+	}
+	return cfg, nil
+}
+
+// fit 在缩放后的特征上训练模型，返回原始单位的参数；缩放器只用 d 拟合
+func (c Config) fit(d data, seed int64, verbose bool) (fitted, error) {
+	scaler, err := scale.New(c.Model.Scale)
+	if err != nil {
+		return fitted{}, err
+	}
+	Xs := scale.FitTransform(scaler, d.table.X)
+	n, _ := Xs.Dims()
+	cfg, err := c.trainConfig(n, seed, verbose)
+	if err != nil {
+		return fitted{}, err
+	}
+
+	var sb float64
+	var sw []float64
+	var hist train.History
+	if c.Model.Type == "logistic" {
+		sb, sw, hist = logreg.GradientDescent(Xs, d.labels, nil, 0, nil, cfg)
+	} else {
+		l, err := loss.New(c.Loss.Name, c.Loss.Param)
+		if err != nil {
+			return fitted{}, err
+		}
+		sb, sw, hist = linreg.GradientDescentMulti(Xs, d.table.Y, 0, nil, l, cfg)
+	}
+	b, w := scaler.UnscaleCoef(sb, sw)
+	return fitted{b: b, w: w, hist: hist, scaler: scaler}, nil
+}
+
+// score 交叉验证使用的得分：线性回归是 R²，逻辑回归是准确率
+func (c Config) score(f fitted, d data) float64 {
+	if d.labels != nil {
+		return metrics.Accuracy(d.labels, logreg.PredictClass(f.b, f.w, d.table.X, 0.5))
+	}
+	return metrics.R2(d.table.Y, linreg.Predict(f.b, f.w, d.table.X))
+}
+
+func (c Config) scoreName() string {
+	if c.Model.Type == "logistic" {
+		return "准确率"
+	}
+	return " R²"
+}
+
+// model 把训练结果转换成可保存的模型，实验设置作为超参数一并记录
+func (c Config) model(f fitted, t datasets.Table, seed int64) *model.Model {
+	sb, sw := f.scaler.ScaleCoef(f.b, f.w)
+	kind := model.Linear
+	if c.Model.Type == "logistic" {
+		kind = model.Logistic
+	}
+	return &model.Model{
+		Kind:      kind,
+		Features:  t.FeatureNames,
+		Target:    t.TargetName,
+		Intercept: sb,
+		Coef:      sw,
+		Scaler:    model.NewScaler(f.scaler),
+		Hyperparams: map[string]interface{}{
+			"experiment": c.Name, "lr": c.Optimizer.LR, "epochs": c.Train.Epochs,
+			"batch": c.Train.Batch, "optimizer": c.Optimizer.Name, "schedule": c.Schedule.Name,
+			"loss": c.Loss.Name, "penalty": c.Model.Penalty.Type, "alpha": c.Model.Penalty.Alpha,
+			"l1_ratio": c.Model.Penalty.l1Ratio(), "scale": f.scaler.Name(), "seed": seed,
+		},
+		Data: model.NewFingerprint(t.X, t.Y),
+	}
+}
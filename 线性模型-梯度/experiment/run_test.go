@@ -0,0 +1,104 @@
+package experiment
+
+import (
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ai/metrics"
+	"ai/model"
+)
+
+func TestRunLinear(t *testing.T) {
+	save := filepath.Join(t.TempDir(), "model.json")
+	cfg, err := Load(writeConfig(t, "linear.json", `{
+  "seed": 1,
+  "dataset": {"generator": "regression", "samples": 200, "coef": [2], "intercept": 1,
+              "x_min": -5, "x_max": 5, "noise": {"scale": 0.1}},
+  "model": {"type": "linear", "scale": "standard"},
+  "optimizer": {"lr": 0.1},
+  "train": {"epochs": 300},
+  "split": {"test_ratio": 0.25, "folds": 3},
+  "metrics": ["r2"],
+  "save": "`+save+`"
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := Run(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(res.Intercept-1) > 0.05 || len(res.Coef) != 1 || math.Abs(res.Coef[0]-2) > 0.05 {
+		t.Errorf("参数为 b=%v w=%v，应接近 b=1 w=[2]", res.Intercept, res.Coef)
+	}
+	// 交叉验证只用训练集的 150 个样本，测试集的 50 个样本单独评估
+	if len(res.CVScores) != 3 || res.CVScores.Mean() < 0.99 {
+		t.Errorf("交叉验证 R² 为 %v，应有 3 折且接近 1", res.CVScores)
+	}
+	if res.Regression.N != 50 || res.Regression.R2 < 0.99 {
+		t.Errorf("测试集上 %d 个样本，R²=%v，应为 50 个样本且接近 1", res.Regression.N, res.Regression.R2)
+	}
+
+	m, err := model.Load(save)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Kind != model.Linear || m.Hyperparams["epochs"] != 300.0 {
+		t.Errorf("保存的模型为 %s，超参数 %v", m.Kind, m.Hyperparams)
+	}
+}
+
+func TestRunLogistic(t *testing.T) {
+	cfg, err := Load(writeConfig(t, "logistic.yaml", `
+seed: 2
+dataset:
+  generator: line
+  samples: 200
+  slope: 1
+  intercept: 0
+  x_min: 0
+  x_max: 10
+  noise:
+    min: -3
+    max: 3
+model:
+  type: logistic
+  scale: standard
+optimizer:
+  lr: 0.5
+train:
+  epochs: 200
+split:
+  test_ratio: 0.2
+  folds: 4
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := Run(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.CVScores) != 4 || res.CVScores.Mean() < 0.8 {
+		t.Errorf("交叉验证准确率为 %v，应有 4 折且不低于 0.8", res.CVScores)
+	}
+	if res.Classification.N != 40 || res.Classification.Accuracy < 0.8 {
+		t.Errorf("测试集上 %d 个样本，准确率 %v，应为 40 个样本且不低于 0.8", res.Classification.N, res.Classification.Accuracy)
+	}
+}
+
+func TestFormatSelected(t *testing.T) {
+	r := metrics.RegressionReport{N: 10, R2: 0.5, RMSE: 2}
+	got := formatRegression(r, []string{"rmse", "r2"})
+	if !strings.Contains(got, "rmse") || !strings.Contains(got, "r2") || strings.Contains(got, "mae") {
+		t.Errorf("只选择 rmse 和 r2 时输出为\n%s", got)
+	}
+	if strings.Index(got, "rmse") > strings.Index(got, "r2 ") {
+		t.Errorf("指标没有按选择的顺序列出：\n%s", got)
+	}
+	if got := formatRegression(r, nil); got != r.String() {
+		t.Errorf("没有选择指标时应输出完整报告，得到\n%s", got)
+	}
+}
@@ -0,0 +1,234 @@
+// Package features 提供特征展开：把原始特征映射到多项式、径向基（RBF）或傅里叶基函数上，
+// 展开后的特征矩阵可以直接交给 linreg 的梯度下降和闭式求解，从而拟合曲线。
+//
+// 展开结果不包含常数列，偏置 b 仍由模型自己负责。
+package features
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Expander 把一个样本的原始特征映射为新特征
+type Expander interface {
+	// Expand 把长度为 NumInputs 的原始特征 x 映射为长度为 NumOutputs 的新特征
+	Expand(x []float64) []float64
+	// NumOutputs 返回展开后的特征个数
+	NumOutputs() int
+	// Names 返回每个展开特征的名称，原始特征记为 x0, x1, ...
+	Names() []string
+}
+
+// Transform 对特征矩阵的每一行做展开，返回 n×NumOutputs 的新矩阵
+func Transform(e Expander, X mat.Matrix) *mat.Dense {
+	n, d := X.Dims()
+	out := mat.NewDense(n, e.NumOutputs(), nil)
+	row := make([]float64, d)
+	for i := 0; i < n; i++ {
+		mat.Row(row, i, X)
+		out.SetRow(i, e.Expand(row))
+	}
+	return out
+}
+
+// Curve 返回单特征展开模型 y = b + w·φ(x) 的预测函数，便于按 x 逐点画出拟合曲线
+func Curve(e Expander, b float64, w []float64) func(x float64) float64 {
+	return func(x float64) float64 {
+		return b + floats.Dot(w, e.Expand([]float64{x}))
+	}
+}
+
+// Polynomial 多项式特征：所有次数在 1 到 Degree 之间的单项式
+type Polynomial struct {
+	Degree       int
+	Interactions bool    // 是否包含不同特征相乘的交叉项，例如 x0*x1
+	Scale        float64 // 展开前先把输入除以 Scale，避免高次项数值过大，0 表示不缩放
+	terms        [][]int // 每个单项式由哪些原始特征相乘得到（可重复）
+}
+
+// NewPolynomial 为 numInputs 个原始特征创建 degree 次多项式展开
+func NewPolynomial(numInputs, degree int, interactions bool, scale float64) *Polynomial {
+	p := &Polynomial{Degree: degree, Interactions: interactions, Scale: scale}
+	for deg := 1; deg <= degree; deg++ {
+		p.terms = append(p.terms, combinations(numInputs, deg, interactions)...)
+	}
+	return p
+}
+
+// combinations 按字典序列出从 n 个特征中可重复地选出 k 个（下标不减）的全部组合；
+// interactions 为 false 时只保留同一个特征的幂
+func combinations(n, k int, interactions bool) [][]int {
+	if !interactions {
+		out := make([][]int, n)
+		for j := range out {
+			out[j] = make([]int, k)
+			for t := range out[j] {
+				out[j][t] = j
+			}
+		}
+		return out
+	}
+
+	var out [][]int
+	var walk func(start int, cur []int)
+	walk = func(start int, cur []int) {
+		if len(cur) == k {
+			out = append(out, append([]int(nil), cur...))
+			return
+		}
+		for j := start; j < n; j++ {
+			walk(j, append(cur, j))
+		}
+	}
+	walk(0, nil)
+	return out
+}
+
+func (p *Polynomial) Expand(x []float64) []float64 {
+	out := make([]float64, len(p.terms))
+	for t, term := range p.terms {
+		v := 1.0
+		for _, j := range term {
+			if p.Scale != 0 {
+				v *= x[j] / p.Scale
+			} else {
+				v *= x[j]
+			}
+		}
+		out[t] = v
+	}
+	return out
+}
+
+func (p *Polynomial) NumOutputs() int {
+	return len(p.terms)
+}
+
+func (p *Polynomial) Names() []string {
+	names := make([]string, len(p.terms))
+	for t, term := range p.terms {
+		// 连续相同的下标合并成幂，例如 [0 0 1] -> x0^2*x1
+		var parts []string
+		for i := 0; i < len(term); {
+			k := i
+			for k < len(term) && term[k] == term[i] {
+				k++
+			}
+			if k-i > 1 {
+				parts = append(parts, fmt.Sprintf("x%d^%d", term[i], k-i))
+			} else {
+				parts = append(parts, fmt.Sprintf("x%d", term[i]))
+			}
+			i = k
+		}
+		names[t] = strings.Join(parts, "*")
+	}
+	return names
+}
+
+// RBF 高斯径向基函数：φ_k(x) = exp(-Gamma * |x - Centers[k]|²)
+type RBF struct {
+	Centers [][]float64
+	Gamma   float64
+}
+
+// NewRBF 以给定的中心和宽度参数创建径向基展开
+func NewRBF(centers [][]float64, gamma float64) *RBF {
+	return &RBF{Centers: centers, Gamma: gamma}
+}
+
+// NewRBFGrid 为单特征输入在 [xMin, xMax] 上均匀放置 count 个中心，
+// 宽度取相邻中心间距，使相邻基函数有适度重叠
+func NewRBFGrid(xMin, xMax float64, count int) *RBF {
+	centers := make([][]float64, count)
+	spacing := xMax - xMin
+	if count > 1 {
+		spacing = (xMax - xMin) / float64(count-1)
+	}
+	for k := range centers {
+		centers[k] = []float64{xMin + float64(k)*spacing}
+	}
+	return NewRBF(centers, 1/(2*spacing*spacing))
+}
+
+func (r *RBF) Expand(x []float64) []float64 {
+	out := make([]float64, len(r.Centers))
+	for k, c := range r.Centers {
+		distSq := 0.0
+		for j := range c {
+			distSq += (x[j] - c[j]) * (x[j] - c[j])
+		}
+		out[k] = math.Exp(-r.Gamma * distSq)
+	}
+	return out
+}
+
+func (r *RBF) NumOutputs() int {
+	return len(r.Centers)
+}
+
+func (r *RBF) Names() []string {
+	names := make([]string, len(r.Centers))
+	for k, c := range r.Centers {
+		names[k] = fmt.Sprintf("rbf(%.3g)", c)
+	}
+	return names
+}
+
+// Fourier 傅里叶基函数：对每个原始特征 x_j 和 k = 1..Terms，
+// 生成 sin(2πk·x_j/Period) 和 cos(2πk·x_j/Period)
+type Fourier struct {
+	NumInputs int
+	Terms     int
+	Period    float64
+}
+
+// NewFourier 为 numInputs 个原始特征创建 terms 阶、周期为 period 的傅里叶展开
+func NewFourier(numInputs, terms int, period float64) *Fourier {
+	return &Fourier{NumInputs: numInputs, Terms: terms, Period: period}
+}
+
+func (f *Fourier) Expand(x []float64) []float64 {
+	out := make([]float64, 0, f.NumOutputs())
+	for j := 0; j < f.NumInputs; j++ {
+		for k := 1; k <= f.Terms; k++ {
+			angle := 2 * math.Pi * float64(k) * x[j] / f.Period
+			out = append(out, math.Sin(angle), math.Cos(angle))
+		}
+	}
+	return out
+}
+
+func (f *Fourier) NumOutputs() int {
+	return 2 * f.NumInputs * f.Terms
+}
+
+func (f *Fourier) Names() []string {
+	names := make([]string, 0, f.NumOutputs())
+	for j := 0; j < f.NumInputs; j++ {
+		for k := 1; k <= f.Terms; k++ {
+			names = append(names, fmt.Sprintf("sin(%d·x%d)", k, j), fmt.Sprintf("cos(%d·x%d)", k, j))
+		}
+	}
+	return names
+}
+
+// New 按名称为单特征输入创建展开，供命令行选择：
+// poly 使用 degree，rbf 和 fourier 使用 count（中心个数或阶数），
+// [xMin, xMax] 是输入的取值范围，用于缩放多项式、放置 RBF 中心和确定傅里叶周期
+// （周期取范围的两倍，避免强迫曲线在两端取相同的值）
+func New(name string, degree, count int, xMin, xMax float64) (Expander, error) {
+	switch name {
+	case "poly":
+		return NewPolynomial(1, degree, false, math.Max(math.Abs(xMin), math.Abs(xMax))), nil
+	case "rbf":
+		return NewRBFGrid(xMin, xMax, count), nil
+	case "fourier":
+		return NewFourier(1, count, 2*(xMax-xMin)), nil
+	}
+	return nil, fmt.Errorf("features: 未知的特征展开 %q，可选：poly, rbf, fourier", name)
+}
@@ -5,9 +5,11 @@ go 1.23.1
 require (
 	github.com/hajimehoshi/ebiten/v2 v2.8.8
 	github.com/pa-m/sklearn v0.0.0-20200711083454-beb861ee48b1
+	golang.org/x/exp v0.0.0-20191129062945-2f5052295587
 	golang.org/x/image v0.29.0
 	gonum.org/v1/gonum v0.9.3
 	gonum.org/v1/plot v0.10.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -25,7 +27,6 @@ require (
 	github.com/pa-m/optimize v0.0.0-20190612075243-15ee852a6d9a // indirect
 	github.com/pa-m/randomkit v0.0.0-20191001073902-db4fd80633df // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	golang.org/x/exp v0.0.0-20191129062945-2f5052295587 // indirect
 	golang.org/x/sync v0.16.0 // indirect
 	golang.org/x/sys v0.33.0 // indirect
 	golang.org/x/text v0.27.0 // indirect
@@ -0,0 +1,127 @@
+// Package gradcheck 用中心差分检查手工推导的梯度。
+//
+// 对每个参数 θ_j，数值梯度取 (f(θ + h·e_j) - f(θ - h·e_j)) / 2h，
+// 再与解析梯度逐个比较相对误差。新增损失函数或模型时，
+// 应在测试中用 Check 或 CheckObjective 在若干随机参数点上验证梯度。
+package gradcheck
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+
+	"ai/train"
+)
+
+// 常用的步长和容差
+const (
+	DefaultEps = 1e-6 // 中心差分的步长
+	DefaultTol = 1e-5 // 相对误差超过该值时认为梯度有误
+)
+
+// minScale 相对误差分母的下限，避免两个梯度都接近 0 时把舍入误差放大成很大的相对误差
+const minScale = 1e-8
+
+// Func 以参数向量为自变量的标量函数，通常是损失函数
+type Func func(params []float64) float64
+
+// Grad 返回 params 处的解析梯度
+type Grad func(params []float64) []float64
+
+// Result 一次梯度检查的结果，每个切片的第 j 个元素对应第 j 个参数
+type Result struct {
+	Params   []float64 // 检查时的参数
+	Analytic []float64 // 解析梯度
+	Numeric  []float64 // 中心差分得到的数值梯度
+	RelErr   []float64 // 相对误差 |a - n| / max(|a|, |n|)
+}
+
+// MaxRelErr 返回所有参数中最大的相对误差
+func (r Result) MaxRelErr() float64 {
+	maxErr := 0.0
+	for _, e := range r.RelErr {
+		maxErr = math.Max(maxErr, e)
+	}
+	return maxErr
+}
+
+// OK 判断所有参数的相对误差是否都不超过 tol
+func (r Result) OK(tol float64) bool {
+	return r.MaxRelErr() <= tol
+}
+
+func (r Result) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%-6s %14s %14s %14s %10s\n", "param", "value", "analytic", "numeric", "rel err")
+	for j := range r.Params {
+		fmt.Fprintf(&sb, "%-6d %14.6g %14.6g %14.6g %10.3g\n", j, r.Params[j], r.Analytic[j], r.Numeric[j], r.RelErr[j])
+	}
+	return sb.String()
+}
+
+// Numerical 用步长 eps 的中心差分计算 f 在 params 处的梯度，params 不会被修改
+func Numerical(f Func, params []float64, eps float64) []float64 {
+	theta := append([]float64(nil), params...)
+	grad := make([]float64, len(params))
+	for j := range theta {
+		theta[j] = params[j] + eps
+		fPlus := f(theta)
+		theta[j] = params[j] - eps
+		fMinus := f(theta)
+		theta[j] = params[j]
+		grad[j] = (fPlus - fMinus) / (2 * eps)
+	}
+	return grad
+}
+
+// RelErr 返回两个数之间的相对误差
+func RelErr(a, b float64) float64 {
+	return math.Abs(a-b) / math.Max(math.Max(math.Abs(a), math.Abs(b)), minScale)
+}
+
+// Check 在 params 处比较解析梯度 grad 与 f 的数值梯度
+func Check(f Func, grad Grad, params []float64, eps float64) Result {
+	r := Result{
+		Params:   append([]float64(nil), params...),
+		Analytic: grad(append([]float64(nil), params...)),
+		Numeric:  Numerical(f, params, eps),
+	}
+	if len(r.Analytic) != len(params) {
+		panic(fmt.Sprintf("gradcheck: 解析梯度长度 %d 与参数个数 %d 不一致", len(r.Analytic), len(params)))
+	}
+	r.RelErr = make([]float64, len(params))
+	for j := range params {
+		r.RelErr[j] = RelErr(r.Analytic[j], r.Numeric[j])
+	}
+	return r
+}
+
+// CheckObjective 在全部样本上检查 obj.LossGrad 给出的梯度与 obj.Loss 是否一致，
+// 同时也检查 LossGrad 返回的损失与 Loss 是否相同
+func CheckObjective(obj train.Objective, params []float64, eps float64) (Result, error) {
+	idx := train.All(obj.NumSamples())
+	var lossGradValue float64
+	r := Check(
+		func(p []float64) float64 { return obj.Loss(p, idx) },
+		func(p []float64) []float64 {
+			g := make([]float64, len(p))
+			lossGradValue = obj.LossGrad(p, idx, g)
+			return g
+		},
+		params, eps,
+	)
+	if loss := obj.Loss(params, idx); RelErr(loss, lossGradValue) > DefaultTol {
+		return r, fmt.Errorf("gradcheck: LossGrad 返回的损失 %g 与 Loss 计算的 %g 不一致", lossGradValue, loss)
+	}
+	return r, nil
+}
+
+// RandomParams 返回 n 个在 [-scale, scale] 内均匀分布的随机参数
+func RandomParams(rng *rand.Rand, n int, scale float64) []float64 {
+	params := make([]float64, n)
+	for j := range params {
+		params[j] = (2*rng.Float64() - 1) * scale
+	}
+	return params
+}
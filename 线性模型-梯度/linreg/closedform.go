@@ -0,0 +1,178 @@
+package linreg
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"ai/train"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Method 最小二乘闭式解的求解方法
+type Method string
+
+const (
+	NormalEquation Method = "normal" // 正规方程 (AᵀA)θ = Aᵀy，用 Cholesky 分解求解
+	QR             Method = "qr"     // 对 A 做 QR 分解，数值上比正规方程稳定
+	SVD            Method = "svd"    // 奇异值分解求伪逆，特征共线时仍能给出最小范数解
+)
+
+// Methods 返回全部闭式求解方法
+func Methods() []Method {
+	return []Method{NormalEquation, QR, SVD}
+}
+
+// svdRcond 小于最大奇异值该倍数的奇异值视为 0
+const svdRcond = 1e-12
+
+// SolveMulti 直接求出多元线性回归 y = X·w + b 的最小二乘解，返回 b 和 w
+// X: 特征矩阵，每行是一个样本
+// y: 目标值
+// method: 求解方法
+func SolveMulti(X mat.Matrix, y []float64, method Method) (float64, []float64, error) {
+	A := designMatrix(X)
+	n, p := A.Dims()
+	yVec := mat.NewVecDense(n, y)
+	theta := mat.NewVecDense(p, nil)
+
+	switch method {
+	case NormalEquation:
+		var ata mat.SymDense
+		ata.SymOuterK(1, A.T())
+		var chol mat.Cholesky
+		if ok := chol.Factorize(&ata); !ok {
+			return 0, nil, errors.New("linreg: AᵀA 不是正定矩阵，特征可能共线，请改用 qr 或 svd")
+		}
+		var aty mat.VecDense
+		aty.MulVec(A.T(), yVec)
+		if err := chol.SolveVecTo(theta, &aty); err != nil {
+			return 0, nil, fmt.Errorf("linreg: 正规方程求解失败: %w", err)
+		}
+	case QR:
+		if n < p {
+			return 0, nil, fmt.Errorf("linreg: 样本数 %d 少于参数个数 %d，QR 无法求解，请改用 svd", n, p)
+		}
+		var qr mat.QR
+		qr.Factorize(A)
+		if err := qr.SolveVecTo(theta, false, yVec); err != nil {
+			return 0, nil, fmt.Errorf("linreg: QR 求解失败: %w", err)
+		}
+	case SVD:
+		var svd mat.SVD
+		if ok := svd.Factorize(A, mat.SVDThin); !ok {
+			return 0, nil, errors.New("linreg: SVD 分解失败")
+		}
+		rank := svd.Rank(svdRcond)
+		if rank == 0 {
+			return 0, nil, errors.New("linreg: 设计矩阵的秩为 0")
+		}
+		svd.SolveVecTo(theta, yVec, rank)
+	default:
+		return 0, nil, fmt.Errorf("linreg: 未知的求解方法 %q，可选：%v", method, Methods())
+	}
+
+	params := theta.RawVector().Data
+	return params[0], append([]float64(nil), params[1:]...), nil
+}
+
+// Solve 直接求出单特征线性回归 y = w*x + b 的最小二乘解，返回 b 和 w
+// points: 样本数据，每个元素是 [x, y] 形式的切片
+func Solve(points [][]float64, method Method) (float64, float64, error) {
+	X, y := PointsToMatrix(points)
+	b, w, err := SolveMulti(X, y, method)
+	if err != nil {
+		return 0, 0, err
+	}
+	return b, w[0], nil
+}
+
+// PointsToMatrix 把 [x, y] 形式的样本拆成 n×1 的特征矩阵和目标值
+func PointsToMatrix(points [][]float64) (*mat.Dense, []float64) {
+	X := mat.NewDense(len(points), 1, nil)
+	y := make([]float64, len(points))
+	for i, p := range points {
+		X.Set(i, 0, p[0])
+		y[i] = p[1]
+	}
+	return X, y
+}
+
+// designMatrix 在特征矩阵左侧加一列 1，使偏置 b 成为第 0 个参数
+func designMatrix(X mat.Matrix) *mat.Dense {
+	n, d := X.Dims()
+	A := mat.NewDense(n, d+1, nil)
+	for i := 0; i < n; i++ {
+		A.Set(i, 0, 1)
+		for j := 0; j < d; j++ {
+			A.Set(i, j+1, X.At(i, j))
+		}
+	}
+	return A
+}
+
+// Comparison 梯度下降结果与最小二乘最优解的对比
+type Comparison struct {
+	Method    Method
+	B, ExactB float64
+	W, ExactW []float64
+	Loss      float64 // 梯度下降结果的均方误差
+	ExactMse  float64 // 最优解的均方误差，即均方误差能达到的下限
+	ParamGap  float64 // 参数向量 [b, w...] 之间的 L2 距离
+}
+
+// LossGap 返回梯度下降结果比最优解多出的均方误差
+func (c Comparison) LossGap() float64 {
+	return c.Loss - c.ExactMse
+}
+
+// Compare 用 method 求出最小二乘最优解，并与梯度下降得到的 b、w 对比
+func Compare(b float64, w []float64, X mat.Matrix, y []float64, method Method) (Comparison, error) {
+	exactB, exactW, err := SolveMulti(X, y, method)
+	if err != nil {
+		return Comparison{}, err
+	}
+	return Comparison{
+		Method:   method,
+		B:        b,
+		ExactB:   exactB,
+		W:        w,
+		ExactW:   exactW,
+		Loss:     MseMulti(b, w, X, y),
+		ExactMse: MseMulti(exactB, exactW, X, y),
+		ParamGap: floats.Distance(append([]float64{b}, w...), append([]float64{exactB}, exactW...), 2),
+	}, nil
+}
+
+// Diagnose 结合训练记录给出对比结论：已收敛、迭代不足，还是学习率设置有问题
+func (c Comparison) Diagnose(hist train.History) string {
+	const relTol = 1e-6
+	losses := hist.Loss
+	last := len(losses) - 1
+
+	switch {
+	case math.IsNaN(c.Loss) || math.IsInf(c.Loss, 0) || (last > 0 && losses[last] > losses[0]):
+		return "损失发散：学习率过大"
+	case c.LossGap() <= relTol*math.Max(c.ExactMse, 1e-12):
+		return "已收敛到最小二乘最优解"
+	case last > 0 && (losses[last-1]-losses[last]) > relTol*losses[last]:
+		return "损失仍在下降：迭代次数不足，可以增加轮数或调大学习率"
+	default:
+		return "损失已停滞但离最优解仍有差距：学习率过小、衰减过快，或批大小带来的噪声过大"
+	}
+}
+
+func (c Comparison) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Closed-form (%s) vs gradient descent:\n", c.Method)
+	fmt.Fprintf(&sb, "  b: exact=%.8f, gd=%.8f, diff=%.3g\n", c.ExactB, c.B, c.B-c.ExactB)
+	for j := range c.W {
+		fmt.Fprintf(&sb, "  w[%d]: exact=%.8f, gd=%.8f, diff=%.3g\n", j, c.ExactW[j], c.W[j], c.W[j]-c.ExactW[j])
+	}
+	fmt.Fprintf(&sb, "  loss: exact=%.8f, gd=%.8f, gap=%.3g\n", c.ExactMse, c.Loss, c.LossGap())
+	fmt.Fprintf(&sb, "  parameter distance: %.3g", c.ParamGap)
+	return sb.String()
+}
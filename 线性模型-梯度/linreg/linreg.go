@@ -0,0 +1,118 @@
+// Package linreg 实现基于梯度下降的线性回归，
+// 包括单特征 y = w*x + b 和多特征 y = X·w + b 两种形式。
+package linreg
+
+import (
+	"ai/loss"
+	"ai/optim"
+	"ai/train"
+)
+
+// Mse 计算均方误差，参数 b 是偏置，w 是权重，points 是样本数据，每个元素是 [x, y] 形式的切片
+func Mse(b, w float64, points [][]float64) float64 {
+	totalError := 0.0
+	// 遍历所有样本点
+	for _, point := range points {
+		x := point[0]
+		y := point[1]
+		// 计算预测值与真实值的差的平方并累加
+		totalError += (y - (w*x + b)) * (y - (w*x + b))
+	}
+	// 求平均得到均方误差
+	return totalError / float64(len(points))
+}
+
+// Gradient 计算均方误差对 b 和 w 的梯度
+// bCurrent: 当前的偏置值
+// wCurrent: 当前的权重值
+// points: 样本数据，每个元素是 [x, y] 形式的切片
+func Gradient(bCurrent, wCurrent float64, points [][]float64) (float64, float64) {
+	bGradient := 0.0
+	wGradient := 0.0
+	M := float64(len(points)) // 总样本数
+
+	for _, point := range points {
+		x := point[0]
+		y := point[1]
+
+		// 计算误差函数对 b 的梯度
+		bGradient += (2 / M) * ((wCurrent*x + bCurrent) - y)
+		// 计算误差函数对 w 的梯度
+		wGradient += (2 / M) * x * ((wCurrent*x + bCurrent) - y)
+	}
+
+	return bGradient, wGradient
+}
+
+// Cost 计算损失函数 l 在全部样本上的平均值，l 为 nil 时等同于 Mse
+func Cost(b, w float64, points [][]float64, l loss.Loss) float64 {
+	if l == nil {
+		return Mse(b, w, points)
+	}
+	totalError := 0.0
+	for _, point := range points {
+		totalError += l.Value(w*point[0] + b - point[1])
+	}
+	return totalError / float64(len(points))
+}
+
+// LossGradient 计算损失函数 l 对 b 和 w 的梯度，l 为 nil 时等同于 Gradient
+func LossGradient(bCurrent, wCurrent float64, points [][]float64, l loss.Loss) (float64, float64) {
+	if l == nil {
+		return Gradient(bCurrent, wCurrent, points)
+	}
+	bGradient := 0.0
+	wGradient := 0.0
+	M := float64(len(points))
+
+	for _, point := range points {
+		x := point[0]
+		y := point[1]
+
+		// 残差 r = w*x + b - y，dr/db = 1，dr/dw = x
+		deriv := l.Deriv(wCurrent*x+bCurrent-y) / M
+		bGradient += deriv
+		wGradient += deriv * x
+	}
+
+	return bGradient, wGradient
+}
+
+// StepGradient 实现梯度下降的一步，更新 b 和 w 的值
+// bCurrent: 当前的偏置值
+// wCurrent: 当前的权重值
+// points: 样本数据，每个元素是 [x, y] 形式的切片
+// lr: 学习率
+// opt: 参数更新规则，为 nil 时使用普通梯度下降 param -= lr * grad
+// l: 损失函数，为 nil 时使用均方误差
+func StepGradient(bCurrent, wCurrent float64, points [][]float64, lr float64, opt optim.Optimizer, l loss.Loss) (float64, float64) {
+	bGradient, wGradient := LossGradient(bCurrent, wCurrent, points, l)
+	if opt == nil {
+		opt = optim.NewSGD()
+	}
+
+	// 参数按 [b, w] 的顺序交给优化器更新
+	params := []float64{bCurrent, wCurrent}
+	opt.Step(params, []float64{bGradient, wGradient}, lr)
+
+	return params[0], params[1]
+}
+
+// GradientDescent 实现梯度下降迭代过程，更新 b 和 w 的值
+// points: 样本数据，每个元素是 [x, y] 形式的切片
+// startingB: b 的初始值
+// startingW: w 的初始值
+// l: 损失函数，为 nil 时使用均方误差
+// cfg: 学习率、轮数、批大小、随机种子、优化器和停止判据等训练配置，
+// BatchSize 为 0 时是全批量梯度下降，为 1 时是随机梯度下降
+// 返回训练后的 b、w 以及每一轮的训练记录
+func GradientDescent(points [][]float64, startingB, startingW float64, l loss.Loss, cfg train.Config) (float64, float64, train.History) {
+	params := []float64{startingB, startingW}
+	var obj train.Objective = Points(points)
+	if l != nil {
+		X, y := PointsToMatrix(points)
+		obj = Data{X: X, Y: y, LossFn: l}
+	}
+	hist := train.Run(obj, params, cfg)
+	return params[0], params[1], hist
+}
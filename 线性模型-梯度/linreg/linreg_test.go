@@ -0,0 +1,145 @@
+package linreg
+
+import (
+	"maps"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"ai/gradcheck"
+	"ai/loss"
+	"ai/train"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// 每个梯度在多少个随机参数点上检查
+const numTrials = 5
+
+// randomPoints 生成 y = 2x - 1 附近的 [x, y] 样本，噪声较大，使残差跨过稳健损失的阈值
+func randomPoints(rng *rand.Rand, n int) [][]float64 {
+	points := make([][]float64, n)
+	for i := range points {
+		x := rng.Float64()*20 - 10
+		points[i] = []float64{x, 2*x - 1 + rng.NormFloat64()*3}
+	}
+	return points
+}
+
+// randomData 生成 n×d 的特征矩阵和目标值
+func randomData(rng *rand.Rand, n, d int) (*mat.Dense, []float64) {
+	X := mat.NewDense(n, d, nil)
+	y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < d; j++ {
+			X.Set(i, j, rng.NormFloat64())
+		}
+		y[i] = rng.NormFloat64() * 3
+	}
+	return X, y
+}
+
+// losses 返回要检查的损失函数，nil 表示默认的均方误差；
+// 各测试按名称排序遍历，保证随机参数点的抽取顺序固定
+func losses(t *testing.T) map[string]loss.Loss {
+	out := map[string]loss.Loss{"default": nil}
+	for _, name := range loss.Names() {
+		l, err := loss.New(name, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out[name] = l
+	}
+	return out
+}
+
+func TestGradient(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	points := randomPoints(rng, 200)
+	for trial := 0; trial < numTrials; trial++ {
+		res := gradcheck.Check(
+			func(p []float64) float64 { return Mse(p[0], p[1], points) },
+			func(p []float64) []float64 {
+				bGrad, wGrad := Gradient(p[0], p[1], points)
+				return []float64{bGrad, wGrad}
+			},
+			gradcheck.RandomParams(rng, 2, 5), gradcheck.DefaultEps,
+		)
+		if !res.OK(gradcheck.DefaultTol) {
+			t.Errorf("Gradient 与 Mse 不一致\n%v", res)
+		}
+	}
+}
+
+func TestLossGradient(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	points := randomPoints(rng, 200)
+	ls := losses(t)
+	for _, name := range slices.Sorted(maps.Keys(ls)) {
+		l := ls[name]
+		for trial := 0; trial < numTrials; trial++ {
+			res := gradcheck.Check(
+				func(p []float64) float64 { return Cost(p[0], p[1], points, l) },
+				func(p []float64) []float64 {
+					bGrad, wGrad := LossGradient(p[0], p[1], points, l)
+					return []float64{bGrad, wGrad}
+				},
+				gradcheck.RandomParams(rng, 2, 5), gradcheck.DefaultEps,
+			)
+			if !res.OK(gradcheck.DefaultTol) {
+				t.Errorf("%s: LossGradient 与 Cost 不一致\n%v", name, res)
+			}
+		}
+	}
+}
+
+func TestLossGradientMulti(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	X, y := randomData(rng, 200, 4)
+	ls := losses(t)
+	for _, name := range slices.Sorted(maps.Keys(ls)) {
+		l := ls[name]
+		for trial := 0; trial < numTrials; trial++ {
+			res := gradcheck.Check(
+				func(p []float64) float64 { return CostMulti(p[0], p[1:], X, y, l) },
+				func(p []float64) []float64 {
+					bGrad, wGrad := LossGradientMulti(p[0], p[1:], X, y, l)
+					return append([]float64{bGrad}, wGrad...)
+				},
+				gradcheck.RandomParams(rng, 5, 2), gradcheck.DefaultEps,
+			)
+			if !res.OK(gradcheck.DefaultTol) {
+				t.Errorf("%s: LossGradientMulti 与 CostMulti 不一致\n%v", name, res)
+			}
+		}
+	}
+}
+
+func TestObjectives(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+	points := randomPoints(rng, 1000)
+	X, y := randomData(rng, 1000, 3)
+
+	objectives := map[string]train.Objective{"Points": Points(points)}
+	for name, l := range losses(t) {
+		objectives["Data/"+name] = Data{X: X, Y: y, LossFn: l}
+		objectives["Parallel/"+name] = train.NewParallel(Data{X: X, Y: y, LossFn: l}, 3)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(objectives)) {
+		obj := objectives[name]
+		numParams := 4
+		if _, ok := obj.(Points); ok {
+			numParams = 2
+		}
+		for trial := 0; trial < numTrials; trial++ {
+			res, err := gradcheck.CheckObjective(obj, gradcheck.RandomParams(rng, numParams, 2), gradcheck.DefaultEps)
+			if err != nil {
+				t.Errorf("%s: %v", name, err)
+			}
+			if !res.OK(gradcheck.DefaultTol) {
+				t.Errorf("%s: LossGrad 与 Loss 不一致\n%v", name, res)
+			}
+		}
+	}
+}
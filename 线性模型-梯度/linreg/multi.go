@@ -0,0 +1,139 @@
+package linreg
+
+import (
+	"fmt"
+
+	"ai/loss"
+	"ai/optim"
+	"ai/train"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Predict 计算多元线性模型的预测值 y = X·w + b
+// b: 偏置
+// w: 权重向量，长度等于 X 的列数
+// X: 特征矩阵，每行是一个样本
+func Predict(b float64, w []float64, X mat.Matrix) []float64 {
+	n, d := X.Dims()
+	if len(w) != d {
+		panic(fmt.Sprintf("linreg: 权重长度 %d 与特征数 %d 不一致", len(w), d))
+	}
+
+	pred := make([]float64, n)
+	predVec := mat.NewVecDense(n, pred)
+	predVec.MulVec(X, mat.NewVecDense(d, w))
+	floats.AddConst(b, pred)
+	return pred
+}
+
+// MseMulti 计算多元线性模型的均方误差
+// b: 偏置
+// w: 权重向量
+// X: 特征矩阵，每行是一个样本
+// y: 目标值，长度等于 X 的行数
+func MseMulti(b float64, w []float64, X mat.Matrix, y []float64) float64 {
+	pred := Predict(b, w, X)
+	totalError := 0.0
+	for i := range pred {
+		totalError += (y[i] - pred[i]) * (y[i] - pred[i])
+	}
+	return totalError / float64(len(y))
+}
+
+// GradientMulti 计算均方误差对 b 和 w 的梯度
+// 残差 r = X·w + b - y，则 dL/db = 2/M * Σr，dL/dw = 2/M * Xᵀ·r
+func GradientMulti(b float64, w []float64, X mat.Matrix, y []float64) (float64, []float64) {
+	n, d := X.Dims()
+	M := float64(n)
+
+	residual := Predict(b, w, X)
+	floats.Sub(residual, y)
+
+	bGradient := 2 / M * floats.Sum(residual)
+
+	wGradient := make([]float64, d)
+	mat.NewVecDense(d, wGradient).MulVec(X.T(), mat.NewVecDense(n, residual))
+	floats.Scale(2/M, wGradient)
+
+	return bGradient, wGradient
+}
+
+// CostMulti 计算损失函数 l 在全部样本上的平均值，l 为 nil 时等同于 MseMulti
+func CostMulti(b float64, w []float64, X mat.Matrix, y []float64, l loss.Loss) float64 {
+	if l == nil {
+		return MseMulti(b, w, X, y)
+	}
+	pred := Predict(b, w, X)
+	totalError := 0.0
+	for i := range pred {
+		totalError += l.Value(pred[i] - y[i])
+	}
+	return totalError / float64(len(y))
+}
+
+// LossGradientMulti 计算损失函数 l 对 b 和 w 的梯度，l 为 nil 时等同于 GradientMulti
+// 令 g_i = l'(r_i)，则 dL/db = 1/M * Σg，dL/dw = 1/M * Xᵀ·g
+func LossGradientMulti(b float64, w []float64, X mat.Matrix, y []float64, l loss.Loss) (float64, []float64) {
+	if l == nil {
+		return GradientMulti(b, w, X, y)
+	}
+	n, d := X.Dims()
+	M := float64(n)
+
+	deriv := Predict(b, w, X)
+	for i := range deriv {
+		deriv[i] = l.Deriv(deriv[i] - y[i])
+	}
+
+	bGradient := floats.Sum(deriv) / M
+
+	wGradient := make([]float64, d)
+	mat.NewVecDense(d, wGradient).MulVec(X.T(), mat.NewVecDense(n, deriv))
+	floats.Scale(1/M, wGradient)
+
+	return bGradient, wGradient
+}
+
+// StepGradientMulti 实现多元线性回归梯度下降的一步，返回更新后的 b 和 w
+// bCurrent: 当前的偏置值
+// wCurrent: 当前的权重向量（不会被修改）
+// X: 特征矩阵
+// y: 目标值
+// lr: 学习率
+// opt: 参数更新规则，为 nil 时使用普通梯度下降
+// l: 损失函数，为 nil 时使用均方误差
+func StepGradientMulti(bCurrent float64, wCurrent []float64, X mat.Matrix, y []float64, lr float64, opt optim.Optimizer, l loss.Loss) (float64, []float64) {
+	bGradient, wGradient := LossGradientMulti(bCurrent, wCurrent, X, y, l)
+	if opt == nil {
+		opt = optim.NewSGD()
+	}
+
+	// 参数按 [b, w...] 的顺序交给优化器更新
+	params := append([]float64{bCurrent}, wCurrent...)
+	opt.Step(params, append([]float64{bGradient}, wGradient...), lr)
+
+	return params[0], params[1:]
+}
+
+// GradientDescentMulti 实现多元线性回归的梯度下降迭代过程
+// X: 特征矩阵，每行是一个样本
+// y: 目标值
+// startingB: b 的初始值
+// startingW: w 的初始值，为 nil 时全部初始化为 0
+// l: 损失函数，为 nil 时使用均方误差
+// cfg: 学习率、轮数、批大小、随机种子、优化器和停止判据等训练配置
+// 返回训练后的 b、w 以及每一轮的训练记录
+func GradientDescentMulti(X *mat.Dense, y []float64, startingB float64, startingW []float64, l loss.Loss, cfg train.Config) (float64, []float64, train.History) {
+	_, d := X.Dims()
+	params := make([]float64, d+1)
+	params[0] = startingB
+	if startingW != nil {
+		copy(params[1:], startingW)
+	}
+
+	hist := train.Run(Data{X: X, Y: y, LossFn: l}, params, cfg)
+
+	return params[0], params[1:], hist
+}
@@ -0,0 +1,95 @@
+package linreg
+
+import (
+	"ai/loss"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Points 把 [x, y] 形式的样本包装成 train.Objective，参数顺序为 [b, w]
+type Points [][]float64
+
+func (p Points) NumSamples() int {
+	return len(p)
+}
+
+// Loss 计算样本子集 idx 上的均方误差
+func (p Points) Loss(params []float64, idx []int) float64 {
+	b, w := params[0], params[1]
+	totalError := 0.0
+	for _, i := range idx {
+		x, y := p[i][0], p[i][1]
+		totalError += (y - (w*x + b)) * (y - (w*x + b))
+	}
+	return totalError / float64(len(idx))
+}
+
+// LossGrad 计算样本子集 idx 上的均方误差及其对 [b, w] 的梯度
+func (p Points) LossGrad(params []float64, idx []int, grad []float64) float64 {
+	b, w := params[0], params[1]
+	M := float64(len(idx))
+	totalError, bGradient, wGradient := 0.0, 0.0, 0.0
+	for _, i := range idx {
+		x, y := p[i][0], p[i][1]
+		err := w*x + b - y
+		totalError += err * err
+		bGradient += (2 / M) * err
+		wGradient += (2 / M) * x * err
+	}
+	grad[0], grad[1] = bGradient, wGradient
+	return totalError / M
+}
+
+// Data 把特征矩阵和目标值包装成 train.Objective，参数顺序为 [b, w...]
+type Data struct {
+	X      *mat.Dense // 特征矩阵，每行是一个样本
+	Y      []float64  // 目标值
+	LossFn loss.Loss  // 逐样本损失，为 nil 时使用均方误差
+}
+
+func (d Data) loss() loss.Loss {
+	if d.LossFn == nil {
+		return loss.MSE{}
+	}
+	return d.LossFn
+}
+
+func (d Data) NumSamples() int {
+	return len(d.Y)
+}
+
+// Loss 计算样本子集 idx 上的平均损失
+func (d Data) Loss(params []float64, idx []int) float64 {
+	b, w := params[0], params[1:]
+	l := d.loss()
+	totalError := 0.0
+	for _, i := range idx {
+		totalError += l.Value(floats.Dot(d.X.RawRowView(i), w) + b - d.Y[i])
+	}
+	return totalError / float64(len(idx))
+}
+
+// LossGrad 计算样本子集 idx 上的平均损失及其对 [b, w...] 的梯度，
+// 由链式法则 dL/dθ = 1/M * Σ l'(r)·∂r/∂θ
+func (d Data) LossGrad(params []float64, idx []int, grad []float64) float64 {
+	b, w := params[0], params[1:]
+	l := d.loss()
+	M := float64(len(idx))
+	for j := range grad {
+		grad[j] = 0
+	}
+
+	totalError := 0.0
+	for _, i := range idx {
+		row := d.X.RawRowView(i)
+		err := floats.Dot(row, w) + b - d.Y[i]
+		totalError += l.Value(err)
+		deriv := l.Deriv(err) / M
+		grad[0] += deriv
+		for j, x := range row {
+			grad[j+1] += deriv * x
+		}
+	}
+	return totalError / M
+}
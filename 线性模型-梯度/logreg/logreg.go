@@ -0,0 +1,187 @@
+// Package logreg 实现任意特征数的二分类逻辑回归：P(y=1 | x) = σ(b + w·x)。
+//
+// 参数与 linreg 一样按 [偏置, 权重...] 排列，b 是偏置，w 的长度等于特征矩阵的列数。
+// σ 和交叉熵都直接由线性部分 g = b + w·x 计算，|g| 很大时也不会出现 log(0)。
+package logreg
+
+import (
+	"fmt"
+	"math"
+
+	"ai/train"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Sigmoid 计算逻辑斯蒂函数 σ(g) = 1 / (1 + e^(-g))；
+// g < 0 时改写成 e^g / (1 + e^g)，避免 e^(-g) 上溢
+func Sigmoid(g float64) float64 {
+	if g >= 0 {
+		return 1 / (1 + math.Exp(-g))
+	}
+	e := math.Exp(g)
+	return e / (1 + e)
+}
+
+// Logit 是 Sigmoid 的反函数 log(p / (1-p))，把概率阈值换算成决策值 b + w·x 上的阈值
+func Logit(p float64) float64 {
+	return math.Log(p / (1 - p))
+}
+
+// LogSigmoid 计算 log σ(g) = -log(1 + e^(-g))；g 很小时 σ(g) 会下溢为 0，
+// 这里改写成 g - log(1 + e^g)，结果始终有限
+func LogSigmoid(g float64) float64 {
+	if g >= 0 {
+		return -math.Log1p(math.Exp(-g))
+	}
+	return g - math.Log1p(math.Exp(g))
+}
+
+// sampleLoss 单个样本的交叉熵，y=1 时为 -log σ(g)，y=0 时为 -log(1-σ(g)) = -log σ(-g)
+func sampleLoss(g float64, y int) float64 {
+	if y == 1 {
+		return -LogSigmoid(g)
+	}
+	return -LogSigmoid(-g)
+}
+
+// Decision 计算每个样本的线性部分 g = X·w + b，g > 0 即正类概率大于 0.5
+// b: 偏置
+// w: 权重向量，长度等于 X 的列数
+// X: 特征矩阵，每行是一个样本
+func Decision(b float64, w []float64, X mat.Matrix) []float64 {
+	n, d := X.Dims()
+	if len(w) != d {
+		panic(fmt.Sprintf("logreg: 权重长度 %d 与特征数 %d 不一致", len(w), d))
+	}
+
+	g := make([]float64, n)
+	mat.NewVecDense(n, g).MulVec(X, mat.NewVecDense(d, w))
+	floats.AddConst(b, g)
+	return g
+}
+
+// PredictProba 计算每个样本属于正类（标签 1）的概率 σ(X·w + b)
+func PredictProba(b float64, w []float64, X mat.Matrix) []float64 {
+	prob := Decision(b, w, X)
+	for i, g := range prob {
+		prob[i] = Sigmoid(g)
+	}
+	return prob
+}
+
+// PredictClass 预测每个样本的类别，正类概率不小于 threshold 时为 1，否则为 0
+func PredictClass(b float64, w []float64, X mat.Matrix, threshold float64) []int {
+	prob := PredictProba(b, w, X)
+	pred := make([]int, len(prob))
+	for i, p := range prob {
+		if p >= threshold {
+			pred[i] = 1
+		}
+	}
+	return pred
+}
+
+// CrossEntropyLoss 计算全部样本上的平均交叉熵损失
+// yTrue: 标签，1 或 0，长度等于 X 的行数
+func CrossEntropyLoss(b float64, w []float64, X mat.Matrix, yTrue []int) float64 {
+	sumLoss := 0.0
+	for i, g := range Decision(b, w, X) {
+		sumLoss += sampleLoss(g, yTrue[i])
+	}
+	return sumLoss / float64(len(yTrue))
+}
+
+// Gradient 计算平均交叉熵对 b 和 w 的梯度
+// 残差 r = σ(X·w + b) - y，则 dJ/db = 1/M * Σr，dJ/dw = 1/M * Xᵀ·r
+func Gradient(b float64, w []float64, X mat.Matrix, yTrue []int) (float64, []float64) {
+	n, d := X.Dims()
+	M := float64(n)
+
+	residual := PredictProba(b, w, X)
+	for i, y := range yTrue {
+		residual[i] -= float64(y)
+	}
+
+	bGradient := floats.Sum(residual) / M
+
+	wGradient := make([]float64, d)
+	mat.NewVecDense(d, wGradient).MulVec(X.T(), mat.NewVecDense(n, residual))
+	floats.Scale(1/M, wGradient)
+
+	return bGradient, wGradient
+}
+
+// Data 把特征矩阵和标签包装成 train.Objective，参数顺序为 [b, w...]；
+// 有样本权重时损失是加权交叉熵 Σ v_i·l_i / M，权重全为 1 时与 CrossEntropyLoss 相同
+type Data struct {
+	X       *mat.Dense // 特征矩阵，每行是一个样本
+	YTrue   []int      // 标签，1 或 0
+	Weights []float64  // 样本权重，为 nil 时每个样本的权重都是 1
+}
+
+// weight 返回第 i 个样本的权重
+func (d Data) weight(i int) float64 {
+	if d.Weights == nil {
+		return 1
+	}
+	return d.Weights[i]
+}
+
+func (d Data) NumSamples() int {
+	return len(d.YTrue)
+}
+
+// Loss 计算样本子集 idx 上的（加权）平均交叉熵损失
+func (d Data) Loss(params []float64, idx []int) float64 {
+	b, w := params[0], params[1:]
+	sumLoss := 0.0
+	for _, i := range idx {
+		sumLoss += d.weight(i) * sampleLoss(floats.Dot(d.X.RawRowView(i), w)+b, d.YTrue[i])
+	}
+	return sumLoss / float64(len(idx))
+}
+
+// LossGrad 计算样本子集 idx 上的（加权）平均交叉熵损失及其对 [b, w...] 的梯度，
+// 每个样本只计算一次线性部分
+func (d Data) LossGrad(params []float64, idx []int, grad []float64) float64 {
+	b, w := params[0], params[1:]
+	M := float64(len(idx))
+	for j := range grad {
+		grad[j] = 0
+	}
+
+	sumLoss := 0.0
+	for _, i := range idx {
+		row := d.X.RawRowView(i)
+		g := floats.Dot(row, w) + b
+		v := d.weight(i)
+		sumLoss += v * sampleLoss(g, d.YTrue[i])
+		diff := v * (Sigmoid(g) - float64(d.YTrue[i])) / M
+		grad[0] += diff
+		floats.AddScaled(grad[1:], diff, row)
+	}
+	return sumLoss / M
+}
+
+// GradientDescent 训练逻辑回归模型
+// X: 特征矩阵，每行是一个样本
+// yTrue: 标签，1 或 0
+// weights: 样本权重，为 nil 时每个样本的权重都是 1
+// startingB: b 的初始值
+// startingW: w 的初始值，为 nil 时全部初始化为 0
+// cfg: 学习率、轮数、优化器和停止判据等训练配置
+// 返回训练后的 b、w 以及每一轮的训练记录
+func GradientDescent(X *mat.Dense, yTrue []int, weights []float64, startingB float64, startingW []float64, cfg train.Config) (float64, []float64, train.History) {
+	_, d := X.Dims()
+	params := make([]float64, d+1)
+	params[0] = startingB
+	if startingW != nil {
+		copy(params[1:], startingW)
+	}
+
+	hist := train.Run(Data{X: X, YTrue: yTrue, Weights: weights}, params, cfg)
+
+	return params[0], params[1:], hist
+}
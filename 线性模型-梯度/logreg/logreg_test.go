@@ -0,0 +1,157 @@
+package logreg
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"ai/gradcheck"
+	"ai/train"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// randomData 生成 n×d 的特征矩阵，标签由随机超平面加少量噪声决定
+func randomData(rng *rand.Rand, n, d int) (*mat.Dense, []int) {
+	X := mat.NewDense(n, d, nil)
+	yTrue := make([]int, n)
+	normal := gradcheck.RandomParams(rng, d, 1)
+	for i := range yTrue {
+		row := X.RawRowView(i)
+		g := rng.NormFloat64() * 0.5
+		for j := range row {
+			row[j] = rng.Float64()*10 - 5
+			g += normal[j] * row[j]
+		}
+		if g > 0 {
+			yTrue[i] = 1
+		}
+	}
+	return X, yTrue
+}
+
+func TestGradients(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, d := range []int{1, 2, 12} {
+		X, yTrue := randomData(rng, 300, d)
+		for trial := 0; trial < 5; trial++ {
+			res := gradcheck.Check(
+				func(p []float64) float64 { return CrossEntropyLoss(p[0], p[1:], X, yTrue) },
+				func(p []float64) []float64 {
+					gb, gw := Gradient(p[0], p[1:], X, yTrue)
+					return append([]float64{gb}, gw...)
+				},
+				gradcheck.RandomParams(rng, d+1, 1), gradcheck.DefaultEps,
+			)
+			if !res.OK(gradcheck.DefaultTol) {
+				t.Errorf("d=%d: Gradient 与 CrossEntropyLoss 不一致\n%v", d, res)
+			}
+		}
+	}
+}
+
+func TestObjective(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	X, yTrue := randomData(rng, 1000, 5)
+	data := Data{X: X, YTrue: yTrue}
+	weighted := Data{X: X, YTrue: yTrue, Weights: randomWeights(rng, 1000)}
+	for _, obj := range []train.Objective{data, train.NewParallel(data, 4), weighted, train.NewParallel(weighted, 4)} {
+		for trial := 0; trial < 5; trial++ {
+			res, err := gradcheck.CheckObjective(obj, gradcheck.RandomParams(rng, 6, 1), gradcheck.DefaultEps)
+			if err != nil {
+				t.Error(err)
+			}
+			if !res.OK(gradcheck.DefaultTol) {
+				t.Errorf("%T: LossGrad 与 Loss 不一致\n%v", obj, res)
+			}
+		}
+	}
+}
+
+// TestStability 检查 |g| 很大时 σ 和交叉熵仍然有限，且与解析的渐近值一致
+func TestStability(t *testing.T) {
+	for _, g := range []float64{-1000, -40, 40, 1000} {
+		if p := Sigmoid(g); math.IsNaN(p) || p < 0 || p > 1 {
+			t.Errorf("Sigmoid(%g) = %g", g, p)
+		}
+		// 被判错的样本的损失约为 |g|，判对的约为 0
+		X := mat.NewDense(1, 1, []float64{g})
+		right := 0
+		if g > 0 {
+			right = 1
+		}
+		wrong := 1 - right
+		if l := CrossEntropyLoss(0, []float64{1}, X, []int{wrong}); math.IsInf(l, 0) || math.Abs(l-math.Abs(g)) > 1e-9*math.Abs(g) {
+			t.Errorf("g=%g 判错时损失为 %g，应约为 %g", g, l, math.Abs(g))
+		}
+		if l := CrossEntropyLoss(0, []float64{1}, X, []int{right}); l < 0 || l > 1e-15 {
+			t.Errorf("g=%g 判对时损失为 %g，应约为 0", g, l)
+		}
+	}
+}
+
+// randomWeights 生成 [0.1, 3) 内的随机样本权重
+func randomWeights(rng *rand.Rand, n int) []float64 {
+	weights := make([]float64, n)
+	for i := range weights {
+		weights[i] = 0.1 + rng.Float64()*2.9
+	}
+	return weights
+}
+
+// TestHessian 用梯度的中心差分检查 hessian 的每一行，包括样本权重和 L2 正则化部分
+func TestHessian(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	X, yTrue := randomData(rng, 300, 4)
+	data := Data{X: X, YTrue: yTrue, Weights: randomWeights(rng, 300)}
+	penalty := train.Ridge(0.1)
+	for trial := 0; trial < 3; trial++ {
+		params := gradcheck.RandomParams(rng, 5, 1)
+		for j := range params {
+			res := gradcheck.Check(
+				func(p []float64) float64 {
+					grad := make([]float64, len(p))
+					objective(data, penalty, p, grad)
+					return grad[j]
+				},
+				func(p []float64) []float64 {
+					return mat.Row(nil, j, hessian(data, penalty, p))
+				},
+				params, gradcheck.DefaultEps,
+			)
+			if !res.OK(gradcheck.DefaultTol) {
+				t.Errorf("Hessian 第 %d 行与梯度的差分不一致\n%v", j, res)
+			}
+		}
+	}
+}
+
+// TestSolvers 检查牛顿法和 L-BFGS 收敛到同一个最优点，且梯度范数足够小
+func TestSolvers(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+	X, yTrue := randomData(rng, 500, 3)
+	for _, penalty := range []train.Penalty{{}, train.Ridge(0.05)} {
+		newton, err := Solve(X, yTrue, nil, Newton, penalty, 100, 1e-10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lbfgs, err := Solve(X, yTrue, nil, LBFGS, penalty, 1000, 1e-10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, r := range []SolveResult{newton, lbfgs} {
+			if r.GradNorm > 1e-6 {
+				t.Errorf("alpha=%g: %v", penalty.Alpha, r)
+			}
+		}
+		if newton.Iterations > 30 {
+			t.Errorf("alpha=%g: 牛顿法迭代了 %d 次，应在几十次内收敛", penalty.Alpha, newton.Iterations)
+		}
+		if math.Abs(newton.Loss-lbfgs.Loss) > 1e-9 || math.Abs(newton.B-lbfgs.B) > 1e-4 {
+			t.Errorf("alpha=%g: 两种方法的结果不一致\n%v\n%v", penalty.Alpha, newton, lbfgs)
+		}
+	}
+	if _, err := Solve(X, yTrue, nil, Newton, train.Lasso(0.1), 100, 1e-10); err == nil {
+		t.Error("L1 正则化应当报错")
+	}
+}
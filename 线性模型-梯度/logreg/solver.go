@@ -0,0 +1,203 @@
+package logreg
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"ai/train"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/optimize"
+)
+
+// Solver 梯度下降以外的求解方法，与 GradientDescent 最小化同一个目标函数：
+// 平均交叉熵 + penalty.Value([b, w...])。L1 部分不可导，这里只支持 L2（ridge）正则化
+type Solver string
+
+const (
+	Newton Solver = "newton" // 牛顿法，对逻辑回归即迭代重加权最小二乘（IRLS）
+	LBFGS  Solver = "lbfgs"  // 有限内存 BFGS 拟牛顿法，使用 gonum 的 optimize 包
+)
+
+// Solvers 返回全部求解方法
+func Solvers() []Solver {
+	return []Solver{Newton, LBFGS}
+}
+
+// SolveResult 求解结果和收敛情况，迭代次数和梯度范数可以直接与 GradientDescent 的训练记录对比
+type SolveResult struct {
+	Solver      Solver
+	B           float64
+	W           []float64
+	Iterations  int           // 牛顿步数或 L-BFGS 的主迭代次数
+	Evaluations int           // 目标函数的求值次数，包括线搜索中的求值
+	Loss        float64       // 最终的目标函数值（含正则化）
+	GradNorm    float64       // 最终梯度的 L2 范数
+	Status      string        // 停止的原因
+	Runtime     time.Duration // 求解用时
+}
+
+func (r SolveResult) String() string {
+	return fmt.Sprintf("%s 共迭代 %d 次（求值 %d 次，用时 %v），最终损失: J=%.6g，梯度范数: %.6g，%s",
+		r.Solver, r.Iterations, r.Evaluations, r.Runtime.Round(time.Microsecond), r.Loss, r.GradNorm, r.Status)
+}
+
+// Solve 用 solver 从全 0 参数出发求解逻辑回归
+// X: 特征矩阵，每行是一个样本
+// yTrue: 0/1 标签
+// weights: 样本权重，为 nil 时每个样本的权重都是 1
+// penalty: 正则化设置，L1 部分必须为 0
+// maxIter: 最大迭代次数
+// tol: 梯度范数小于 tol 时停止；L-BFGS 按 gonum 的约定比较梯度的无穷范数
+func Solve(X *mat.Dense, yTrue []int, weights []float64, solver Solver, penalty train.Penalty, maxIter int, tol float64) (SolveResult, error) {
+	if penalty.L1() > 0 {
+		return SolveResult{}, fmt.Errorf("logreg: %s 要求目标函数可导，不支持 L1 正则化，请改用梯度下降", solver)
+	}
+	d := Data{X: X, YTrue: yTrue, Weights: weights}
+	start := time.Now()
+	var (
+		res SolveResult
+		err error
+	)
+	switch solver {
+	case Newton:
+		res, err = solveNewton(d, penalty, maxIter, tol)
+	case LBFGS:
+		res, err = solveLBFGS(d, penalty, maxIter, tol)
+	default:
+		return SolveResult{}, fmt.Errorf("logreg: 未知的求解方法 %q，可选：%v", solver, Solvers())
+	}
+	if err != nil {
+		return SolveResult{}, err
+	}
+	res.Solver = solver
+	res.Runtime = time.Since(start)
+	return res, nil
+}
+
+// objective 计算参数 [b, w...] 处全部样本上的目标函数值，grad 不为 nil 时同时写入梯度
+func objective(data Data, penalty train.Penalty, params, grad []float64) float64 {
+	all := train.All(data.NumSamples())
+	var loss float64
+	if grad != nil {
+		loss = data.LossGrad(params, all, grad)
+		penalty.AddGrad(params, grad)
+	} else {
+		loss = data.Loss(params, all)
+	}
+	return loss + penalty.Value(params)
+}
+
+// hessian 计算目标函数的 Hessian 矩阵 H = 1/M * Aᵀ·S·A + L2 部分，
+// 其中 A = [1, X] 是设计矩阵，S = diag(v_i·p_i(1-p_i))，v_i 是样本权重
+func hessian(data Data, penalty train.Penalty, params []float64) *mat.SymDense {
+	n, d := data.X.Dims()
+	M := float64(n)
+	prob := PredictProba(params[0], params[1:], data.X)
+
+	// 每行乘以 sqrt(v·p(1-p)/M)，H 就是它的 Gram 矩阵
+	B := mat.NewDense(n, d+1, nil)
+	for i, p := range prob {
+		s := math.Sqrt(data.weight(i) * p * (1 - p) / M)
+		row := B.RawRowView(i)
+		row[0] = s
+		floats.ScaleTo(row[1:], s, data.X.RawRowView(i))
+	}
+	H := mat.NewSymDense(d+1, nil)
+	H.SymOuterK(1, B.T())
+
+	// L2 部分的 Hessian 是对角阵，对角元就是参数全为 1 时 AddGrad 累加的值
+	ones := make([]float64, d+1)
+	floats.AddConst(1, ones)
+	diag := make([]float64, d+1)
+	penalty.AddGrad(ones, diag)
+	for j, v := range diag {
+		H.SetSym(j, j, H.At(j, j)+v)
+	}
+	return H
+}
+
+// solveNewton 牛顿法：每一步解 H·Δ = ∇J 并令 θ = θ - t·Δ。
+// 对逻辑回归这等价于 IRLS：以 v·p(1-p) 为样本权重、以 z = Aθ + (y-p)/(p(1-p)) 为响应做加权最小二乘。
+// 离最优点较远时完整的牛顿步可能让损失上升，因此用回溯线搜索把步长 t 减半，直到满足 Armijo 条件
+func solveNewton(data Data, penalty train.Penalty, maxIter int, tol float64) (SolveResult, error) {
+	_, d := data.X.Dims()
+	params := make([]float64, d+1)
+	grad := make([]float64, d+1)
+	loss := objective(data, penalty, params, grad)
+	res := SolveResult{Evaluations: 1, Status: fmt.Sprintf("达到最大迭代次数 %d", maxIter)}
+
+	trial := make([]float64, d+1)
+	trialGrad := make([]float64, d+1)
+	step := mat.NewVecDense(d+1, nil)
+	for ; res.Iterations < maxIter; res.Iterations++ {
+		if norm := floats.Norm(grad, 2); norm < tol {
+			res.Status = fmt.Sprintf("梯度范数 %.3g 小于 %g", norm, tol)
+			break
+		}
+
+		var chol mat.Cholesky
+		if ok := chol.Factorize(hessian(data, penalty, params)); !ok {
+			return SolveResult{}, errors.New("logreg: Hessian 不是正定矩阵，数据可能线性可分或特征共线，可以加 ridge 正则化或改用 lbfgs")
+		}
+		if err := chol.SolveVecTo(step, mat.NewVecDense(d+1, grad)); err != nil {
+			return SolveResult{}, fmt.Errorf("logreg: 牛顿方程求解失败: %w", err)
+		}
+
+		// 接近最优点时损失的下降量会小于浮点舍入误差，留出 slack 以免线搜索把步长一直减半
+		const armijo = 1e-4
+		decrease := floats.Dot(grad, step.RawVector().Data)
+		slack := 1e-14 * math.Abs(loss)
+		t := 1.0
+		var trialLoss float64
+		for {
+			floats.AddScaledTo(trial, params, -t, step.RawVector().Data)
+			trialLoss = objective(data, penalty, trial, trialGrad)
+			res.Evaluations++
+			if trialLoss <= loss-armijo*t*decrease+slack || t < 1e-10 {
+				break
+			}
+			t /= 2
+		}
+		copy(params, trial)
+		copy(grad, trialGrad)
+		loss = trialLoss
+	}
+
+	res.B, res.W = params[0], append([]float64(nil), params[1:]...)
+	res.Loss, res.GradNorm = loss, floats.Norm(grad, 2)
+	return res, nil
+}
+
+// solveLBFGS 用 gonum optimize 的 L-BFGS 求解，只需要目标函数和梯度
+func solveLBFGS(data Data, penalty train.Penalty, maxIter int, tol float64) (SolveResult, error) {
+	_, d := data.X.Dims()
+	problem := optimize.Problem{
+		Func: func(params []float64) float64 {
+			return objective(data, penalty, params, nil)
+		},
+		Grad: func(grad, params []float64) {
+			objective(data, penalty, params, grad)
+		},
+	}
+	settings := &optimize.Settings{GradientThreshold: tol, MajorIterations: maxIter}
+	r, err := optimize.Minimize(problem, make([]float64, d+1), settings, &optimize.LBFGS{})
+	if err != nil {
+		return SolveResult{}, fmt.Errorf("logreg: L-BFGS 求解失败: %w", err)
+	}
+
+	grad := make([]float64, d+1)
+	loss := objective(data, penalty, r.X, grad)
+	return SolveResult{
+		B:           r.X[0],
+		W:           append([]float64(nil), r.X[1:]...),
+		Iterations:  r.MajorIterations,
+		Evaluations: r.FuncEvaluations,
+		Loss:        loss,
+		GradNorm:    floats.Norm(grad, 2),
+		Status:      r.Status.String(),
+	}, nil
+}
@@ -0,0 +1,160 @@
+// Package loss 定义回归模型的逐样本损失函数。
+//
+// 所有损失都以残差 r = 预测值 - 真实值 为自变量。除均方误差外，
+// 还提供对离群点不敏感的稳健损失：绝对误差、Huber、Tukey 双权和分位数（pinball）损失。
+package loss
+
+import (
+	"fmt"
+	"math"
+)
+
+// Loss 逐样本的回归损失
+type Loss interface {
+	// Value 返回残差 r 上的损失
+	Value(r float64) float64
+	// Deriv 返回损失对残差 r 的导数，不可导的点取 0 作为次梯度
+	Deriv(r float64) float64
+}
+
+// 各损失参数的常用默认值
+const (
+	DefaultHuberDelta = 1.345 // 正态噪声下达到 95% 效率的 Huber 阈值
+	DefaultTukeyC     = 4.685 // 正态噪声下达到 95% 效率的 Tukey 阈值
+	DefaultQuantile   = 0.5   // 0.5 分位数即中位数回归
+)
+
+// MSE 平方误差 r²，对大残差的惩罚随残差平方增长，容易被离群点拉偏
+type MSE struct{}
+
+func (MSE) Value(r float64) float64 { return r * r }
+func (MSE) Deriv(r float64) float64 { return 2 * r }
+
+// MAE 绝对误差 |r|，拟合的是条件中位数，对离群点不敏感
+type MAE struct{}
+
+func (MAE) Value(r float64) float64 { return math.Abs(r) }
+
+func (MAE) Deriv(r float64) float64 {
+	switch {
+	case r > 0:
+		return 1
+	case r < 0:
+		return -1
+	}
+	return 0
+}
+
+// Huber 在 |r| <= Delta 时是 r²/2，超出后变为线性 Delta*(|r| - Delta/2)，
+// 兼顾小残差处的平滑和大残差处的稳健
+type Huber struct {
+	Delta float64
+}
+
+// NewHuber 创建阈值为 delta 的 Huber 损失
+func NewHuber(delta float64) *Huber {
+	return &Huber{Delta: delta}
+}
+
+func (h *Huber) Value(r float64) float64 {
+	a := math.Abs(r)
+	if a <= h.Delta {
+		return r * r / 2
+	}
+	return h.Delta * (a - h.Delta/2)
+}
+
+func (h *Huber) Deriv(r float64) float64 {
+	return math.Max(-h.Delta, math.Min(h.Delta, r))
+}
+
+// Tukey 双权损失：|r| <= C 时为 C²/6 * (1 - (1 - (r/C)²)³)，超出后恒为 C²/6。
+// 残差大于 C 的样本梯度为 0，完全不影响拟合；
+// 但损失非凸，初始参数离最优解太远时所有样本都可能落在 C 之外，
+// 此时应先用 Huber 或 MAE 得到一个初值
+type Tukey struct {
+	C float64
+}
+
+// NewTukey 创建阈值为 c 的 Tukey 双权损失
+func NewTukey(c float64) *Tukey {
+	return &Tukey{C: c}
+}
+
+func (t *Tukey) Value(r float64) float64 {
+	if math.Abs(r) > t.C {
+		return t.C * t.C / 6
+	}
+	u := 1 - (r/t.C)*(r/t.C)
+	return t.C * t.C / 6 * (1 - u*u*u)
+}
+
+func (t *Tukey) Deriv(r float64) float64 {
+	if math.Abs(r) > t.C {
+		return 0
+	}
+	u := 1 - (r/t.C)*(r/t.C)
+	return r * u * u
+}
+
+// Quantile 分位数（pinball）损失，拟合目标值的 Tau 分位数：
+// 预测偏低（r < 0）时损失为 Tau*|r|，偏高时为 (1-Tau)*|r|
+type Quantile struct {
+	Tau float64
+}
+
+// NewQuantile 创建 tau 分位数的 pinball 损失，tau 取值 (0, 1)
+func NewQuantile(tau float64) *Quantile {
+	return &Quantile{Tau: tau}
+}
+
+func (q *Quantile) Value(r float64) float64 {
+	if r < 0 {
+		return -q.Tau * r
+	}
+	return (1 - q.Tau) * r
+}
+
+func (q *Quantile) Deriv(r float64) float64 {
+	switch {
+	case r < 0:
+		return -q.Tau
+	case r > 0:
+		return 1 - q.Tau
+	}
+	return 0
+}
+
+// Names 返回 New 支持的损失名称
+func Names() []string {
+	return []string{"mse", "mae", "huber", "tukey", "quantile"}
+}
+
+// New 按名称创建损失，供命令行选择。
+// param 是 huber 的 Delta、tukey 的 C 或 quantile 的 Tau，为 0 时使用默认值；mse 和 mae 忽略 param
+func New(name string, param float64) (Loss, error) {
+	switch name {
+	case "mse":
+		return MSE{}, nil
+	case "mae":
+		return MAE{}, nil
+	case "huber":
+		return NewHuber(orDefault(param, DefaultHuberDelta)), nil
+	case "tukey":
+		return NewTukey(orDefault(param, DefaultTukeyC)), nil
+	case "quantile":
+		tau := orDefault(param, DefaultQuantile)
+		if tau <= 0 || tau >= 1 {
+			return nil, fmt.Errorf("loss: 分位数必须在 (0, 1) 内，实际为 %g", tau)
+		}
+		return NewQuantile(tau), nil
+	}
+	return nil, fmt.Errorf("loss: 未知的损失函数 %q，可选：%v", name, Names())
+}
+
+func orDefault(v, def float64) float64 {
+	if v == 0 {
+		return def
+	}
+	return v
+}
@@ -0,0 +1,30 @@
+package loss
+
+import (
+	"math/rand"
+	"testing"
+
+	"ai/gradcheck"
+)
+
+// TestDeriv 在随机残差上用中心差分检查每个损失的导数
+func TestDeriv(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, name := range Names() {
+		l, err := New(name, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for trial := 0; trial < 20; trial++ {
+			r := gradcheck.RandomParams(rng, 1, 10)
+			res := gradcheck.Check(
+				func(p []float64) float64 { return l.Value(p[0]) },
+				func(p []float64) []float64 { return []float64{l.Deriv(p[0])} },
+				r, gradcheck.DefaultEps,
+			)
+			if !res.OK(gradcheck.DefaultTol) {
+				t.Errorf("%s: 导数与数值梯度不一致\n%v", name, res)
+			}
+		}
+	}
+}
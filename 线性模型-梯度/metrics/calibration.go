@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// BrierScore 正类概率与 0/1 标签之差的平方的均值，越小越好；
+// 它同时惩罚区分能力差和概率不准，总是预测正类比例时等于 p(1-p)
+func BrierScore(yTrue []int, prob []float64) float64 {
+	sum := 0.0
+	for i, y := range yTrue {
+		d := prob[i] - float64(y)
+		sum += d * d
+	}
+	return sum / float64(len(yTrue))
+}
+
+// ReliabilityBin 可靠性图中的一个概率区间 [Lower, Upper)，最后一个区间包含 1
+type ReliabilityBin struct {
+	Lower, Upper float64
+	MeanProb     float64 // 区间内样本的平均预测概率
+	FracPos      float64 // 区间内正类样本的实际比例
+	Count        int
+}
+
+// ReliabilityCurve 把 [0, 1] 等分成 bins 个区间，按预测概率把样本分进区间，
+// 返回每个非空区间的平均预测概率和实际正类比例；概率准确时各点落在对角线上
+func ReliabilityCurve(yTrue []int, prob []float64, bins int) []ReliabilityBin {
+	sumProb := make([]float64, bins)
+	pos := make([]int, bins)
+	count := make([]int, bins)
+	for i, p := range prob {
+		b := min(int(p*float64(bins)), bins-1)
+		sumProb[b] += p
+		pos[b] += yTrue[i]
+		count[b]++
+	}
+
+	var curve []ReliabilityBin
+	for b := 0; b < bins; b++ {
+		if count[b] == 0 {
+			continue
+		}
+		curve = append(curve, ReliabilityBin{
+			Lower:    float64(b) / float64(bins),
+			Upper:    float64(b+1) / float64(bins),
+			MeanProb: sumProb[b] / float64(count[b]),
+			FracPos:  float64(pos[b]) / float64(count[b]),
+			Count:    count[b],
+		})
+	}
+	return curve
+}
+
+// ExpectedCalibrationError 各区间平均预测概率与实际正类比例之差的绝对值，按样本数加权平均
+func ExpectedCalibrationError(curve []ReliabilityBin) float64 {
+	sum, n := 0.0, 0
+	for _, b := range curve {
+		sum += float64(b.Count) * math.Abs(b.MeanProb-b.FracPos)
+		n += b.Count
+	}
+	return sum / float64(n)
+}
+
+// CalibrationReport 一组正类概率的校准情况
+type CalibrationReport struct {
+	Name    string // 概率的来源，例如 "uncalibrated"、"platt"，用作图例
+	N       int
+	Brier   float64
+	LogLoss float64
+	ECE     float64 // ExpectedCalibrationError
+	Curve   []ReliabilityBin
+}
+
+// EvaluateCalibration 用 bins 个等宽区间计算可靠性曲线和 Brier 分数等校准指标
+func EvaluateCalibration(name string, yTrue []int, prob []float64, bins int) CalibrationReport {
+	curve := ReliabilityCurve(yTrue, prob, bins)
+	return CalibrationReport{
+		Name:    name,
+		N:       len(yTrue),
+		Brier:   BrierScore(yTrue, prob),
+		LogLoss: LogLoss(yTrue, prob),
+		ECE:     ExpectedCalibrationError(curve),
+		Curve:   curve,
+	}
+}
+
+// Summary 返回适合放在图例中的一行摘要
+func (r CalibrationReport) Summary() string {
+	return fmt.Sprintf("Brier=%.4f, ECE=%.4f", r.Brier, r.ECE)
+}
+
+func (r CalibrationReport) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s（%d 个样本）：Brier=%.6g，log loss=%.6g，ECE=%.6g\n", r.Name, r.N, r.Brier, r.LogLoss, r.ECE)
+	fmt.Fprintf(&sb, "%15s %12s %12s %8s\n", "bin", "mean prob", "frac pos", "count")
+	for _, b := range r.Curve {
+		fmt.Fprintf(&sb, "%15s %12.4f %12.4f %8d\n", fmt.Sprintf("[%.2f, %.2f)", b.Lower, b.Upper), b.MeanProb, b.FracPos, b.Count)
+	}
+	return sb.String()
+}
@@ -0,0 +1,370 @@
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Predict 把正类概率按阈值转换成 0/1 标签，概率不小于 threshold 时判为 1
+func Predict(prob []float64, threshold float64) []int {
+	pred := make([]int, len(prob))
+	for i, p := range prob {
+		if p >= threshold {
+			pred[i] = 1
+		}
+	}
+	return pred
+}
+
+// Accuracy 准确率
+func Accuracy(yTrue, yPred []int) float64 {
+	correct := 0
+	for i, y := range yTrue {
+		if y == yPred[i] {
+			correct++
+		}
+	}
+	return float64(correct) / float64(len(yTrue))
+}
+
+// ConfusionMatrix 混淆矩阵，第 i 行第 j 列是真实类别为 i、预测为 j 的样本数
+func ConfusionMatrix(yTrue, yPred []int, numClasses int) [][]int {
+	cm := make([][]int, numClasses)
+	for i := range cm {
+		cm[i] = make([]int, numClasses)
+	}
+	for i, y := range yTrue {
+		cm[y][yPred[i]]++
+	}
+	return cm
+}
+
+// Precision 把 positive 当作正类时的精确率 TP/(TP+FP)，没有预测为正类的样本时返回 0
+func Precision(yTrue, yPred []int, positive int) float64 {
+	tp, fp, _ := counts(yTrue, yPred, positive)
+	return ratio(tp, tp+fp)
+}
+
+// Recall 把 positive 当作正类时的召回率 TP/(TP+FN)，没有真实为正类的样本时返回 0
+func Recall(yTrue, yPred []int, positive int) float64 {
+	tp, _, fn := counts(yTrue, yPred, positive)
+	return ratio(tp, tp+fn)
+}
+
+// F1 精确率和召回率的调和平均 2TP/(2TP+FP+FN)
+func F1(yTrue, yPred []int, positive int) float64 {
+	tp, fp, fn := counts(yTrue, yPred, positive)
+	return ratio(2*tp, 2*tp+fp+fn)
+}
+
+// BalancedAccuracy 各类别召回率的平均值，只统计在 yTrue 中出现过的类别，类别不平衡时比准确率更可靠
+func BalancedAccuracy(yTrue, yPred []int) float64 {
+	total := make(map[int]int)
+	correct := make(map[int]int)
+	for i, y := range yTrue {
+		total[y]++
+		if y == yPred[i] {
+			correct[y]++
+		}
+	}
+	sum := 0.0
+	for y, n := range total {
+		sum += float64(correct[y]) / float64(n)
+	}
+	return sum / float64(len(total))
+}
+
+// LogLoss 二分类的平均交叉熵，prob 是正类概率，被截断到 [1e-15, 1-1e-15] 以免出现 log(0)
+func LogLoss(yTrue []int, prob []float64) float64 {
+	const eps = 1e-15
+	sum := 0.0
+	for i, y := range yTrue {
+		p := math.Min(math.Max(prob[i], eps), 1-eps)
+		if y == 1 {
+			sum -= math.Log(p)
+		} else {
+			sum -= math.Log(1 - p)
+		}
+	}
+	return sum / float64(len(yTrue))
+}
+
+// Curve 按阈值从高到低排列的曲线上的点，X、Y 的含义由生成函数决定，可以直接交给 plotter 绘制
+type Curve struct {
+	X, Y       []float64
+	Thresholds []float64
+}
+
+// ROCCurve 返回 ROC 曲线，X 为假正率、Y 为真正率；score 越大越倾向正类（1），
+// 得分相同的样本在同一个阈值上一起计入，曲线从 (0, 0) 开始
+func ROCCurve(yTrue []int, score []float64) Curve {
+	pos, neg := classCounts(yTrue)
+	c := Curve{X: []float64{0}, Y: []float64{0}, Thresholds: []float64{math.Inf(1)}}
+	tp, fp := 0, 0
+	for _, g := range thresholdGroups(yTrue, score) {
+		tp += g.pos
+		fp += g.neg
+		c.X = append(c.X, ratio(fp, neg))
+		c.Y = append(c.Y, ratio(tp, pos))
+		c.Thresholds = append(c.Thresholds, g.threshold)
+	}
+	return c
+}
+
+// PRCurve 返回精确率-召回率曲线，X 为召回率、Y 为精确率，阈值从高到低
+func PRCurve(yTrue []int, score []float64) Curve {
+	pos, _ := classCounts(yTrue)
+	var c Curve
+	tp, fp := 0, 0
+	for _, g := range thresholdGroups(yTrue, score) {
+		tp += g.pos
+		fp += g.neg
+		c.X = append(c.X, ratio(tp, pos))
+		c.Y = append(c.Y, ratio(tp, tp+fp))
+		c.Thresholds = append(c.Thresholds, g.threshold)
+	}
+	return c
+}
+
+// ROCAUC ROC 曲线下的面积（梯形法），只有一个类别时没有定义，返回 NaN
+func ROCAUC(yTrue []int, score []float64) float64 {
+	if pos, neg := classCounts(yTrue); pos == 0 || neg == 0 {
+		return math.NaN()
+	}
+	c := ROCCurve(yTrue, score)
+	area := 0.0
+	for i := 1; i < len(c.X); i++ {
+		area += (c.X[i] - c.X[i-1]) * (c.Y[i] + c.Y[i-1]) / 2
+	}
+	return area
+}
+
+// PRAUC 精确率-召回率曲线下的面积，按 scikit-learn 的 average_precision_score 计算：
+// 各阈值处精确率按召回率的增量加权求和，不做插值；没有正类样本时返回 NaN
+func PRAUC(yTrue []int, score []float64) float64 {
+	if pos, _ := classCounts(yTrue); pos == 0 {
+		return math.NaN()
+	}
+	c := PRCurve(yTrue, score)
+	area, prevRecall := 0.0, 0.0
+	for i := range c.X {
+		area += (c.X[i] - prevRecall) * c.Y[i]
+		prevRecall = c.X[i]
+	}
+	return area
+}
+
+// ClassificationReport 二分类模型的全部评估指标，正类为 1
+type ClassificationReport struct {
+	N                int
+	Threshold        float64
+	Accuracy         float64
+	Precision        float64
+	Recall           float64
+	F1               float64
+	BalancedAccuracy float64
+	LogLoss          float64
+	ROCAUC           float64
+	PRAUC            float64
+	Confusion        [][]int // 2x2 混淆矩阵，行是真实类别，列是预测类别
+}
+
+// EvaluateBinary 由正类概率计算全部二分类指标，概率不小于 threshold 时判为正类
+func EvaluateBinary(yTrue []int, prob []float64, threshold float64) ClassificationReport {
+	pred := Predict(prob, threshold)
+	return ClassificationReport{
+		N:                len(yTrue),
+		Threshold:        threshold,
+		Accuracy:         Accuracy(yTrue, pred),
+		Precision:        Precision(yTrue, pred, 1),
+		Recall:           Recall(yTrue, pred, 1),
+		F1:               F1(yTrue, pred, 1),
+		BalancedAccuracy: BalancedAccuracy(yTrue, pred),
+		LogLoss:          LogLoss(yTrue, prob),
+		ROCAUC:           ROCAUC(yTrue, prob),
+		PRAUC:            PRAUC(yTrue, prob),
+		Confusion:        ConfusionMatrix(yTrue, pred, 2),
+	}
+}
+
+// Summary 返回适合放在图像标题中的一行摘要
+func (r ClassificationReport) Summary() string {
+	return fmt.Sprintf("Acc=%.4f, F1=%.4f, AUC=%.4f", r.Accuracy, r.F1, r.ROCAUC)
+}
+
+func (r ClassificationReport) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%-20s %12s\n", "metric", "value")
+	row := func(name string, v float64) { fmt.Fprintf(&sb, "%-20s %12.6g\n", name, v) }
+	row("accuracy", r.Accuracy)
+	row("precision", r.Precision)
+	row("recall", r.Recall)
+	row("F1", r.F1)
+	row("balanced accuracy", r.BalancedAccuracy)
+	row("log loss", r.LogLoss)
+	row("ROC-AUC", r.ROCAUC)
+	row("PR-AUC", r.PRAUC)
+	row("threshold", r.Threshold)
+	fmt.Fprintf(&sb, "%-20s %12d\n", "samples", r.N)
+	fmt.Fprintf(&sb, "confusion matrix (rows: true, cols: predicted)\n")
+	fmt.Fprintf(&sb, "%8s %8s %8s\n", "", "pred 0", "pred 1")
+	for i, row := range r.Confusion {
+		fmt.Fprintf(&sb, "%8s %8d %8d\n", fmt.Sprintf("true %d", i), row[0], row[1])
+	}
+	return sb.String()
+}
+
+// ArgMax 把每个样本各类别的概率转换成概率最大的类别
+func ArgMax(prob [][]float64) []int {
+	pred := make([]int, len(prob))
+	for i, p := range prob {
+		for c, v := range p {
+			if v > p[pred[i]] {
+				pred[i] = c
+			}
+		}
+	}
+	return pred
+}
+
+// MacroF1 把每个类别依次当作正类求 F1 再取平均，numClasses 个类别等权
+func MacroF1(yTrue, yPred []int, numClasses int) float64 {
+	sum := 0.0
+	for c := 0; c < numClasses; c++ {
+		sum += F1(yTrue, yPred, c)
+	}
+	return sum / float64(numClasses)
+}
+
+// CategoricalLogLoss 多分类的平均交叉熵 -log p_y，prob[i] 是第 i 个样本各类别的概率，
+// 与 LogLoss 一样截断到 [1e-15, 1-1e-15]
+func CategoricalLogLoss(yTrue []int, prob [][]float64) float64 {
+	const eps = 1e-15
+	sum := 0.0
+	for i, y := range yTrue {
+		sum -= math.Log(math.Min(math.Max(prob[i][y], eps), 1-eps))
+	}
+	return sum / float64(len(yTrue))
+}
+
+// MulticlassReport 多分类模型的评估指标，预测类别是概率最大的类别
+type MulticlassReport struct {
+	N                int
+	K                int // 类别数
+	Accuracy         float64
+	BalancedAccuracy float64
+	MacroF1          float64
+	LogLoss          float64
+	Confusion        [][]int // K×K 混淆矩阵，行是真实类别，列是预测类别
+}
+
+// EvaluateMulticlass 由每个样本各类别的概率计算多分类指标，类别数等于 prob 每行的长度
+func EvaluateMulticlass(yTrue []int, prob [][]float64) MulticlassReport {
+	k := len(prob[0])
+	pred := ArgMax(prob)
+	return MulticlassReport{
+		N:                len(yTrue),
+		K:                k,
+		Accuracy:         Accuracy(yTrue, pred),
+		BalancedAccuracy: BalancedAccuracy(yTrue, pred),
+		MacroF1:          MacroF1(yTrue, pred, k),
+		LogLoss:          CategoricalLogLoss(yTrue, prob),
+		Confusion:        ConfusionMatrix(yTrue, pred, k),
+	}
+}
+
+// Summary 返回适合放在图像标题中的一行摘要
+func (r MulticlassReport) Summary() string {
+	return fmt.Sprintf("Acc=%.4f, Macro-F1=%.4f", r.Accuracy, r.MacroF1)
+}
+
+func (r MulticlassReport) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%-20s %12s\n", "metric", "value")
+	row := func(name string, v float64) { fmt.Fprintf(&sb, "%-20s %12.6g\n", name, v) }
+	row("accuracy", r.Accuracy)
+	row("balanced accuracy", r.BalancedAccuracy)
+	row("macro F1", r.MacroF1)
+	row("log loss", r.LogLoss)
+	fmt.Fprintf(&sb, "%-20s %12d\n", "classes", r.K)
+	fmt.Fprintf(&sb, "%-20s %12d\n", "samples", r.N)
+	fmt.Fprintf(&sb, "confusion matrix (rows: true, cols: predicted)\n")
+	fmt.Fprintf(&sb, "%8s", "")
+	for c := 0; c < r.K; c++ {
+		fmt.Fprintf(&sb, " %8s", fmt.Sprintf("pred %d", c))
+	}
+	sb.WriteString("\n")
+	for i, row := range r.Confusion {
+		fmt.Fprintf(&sb, "%8s", fmt.Sprintf("true %d", i))
+		for _, v := range row {
+			fmt.Fprintf(&sb, " %8d", v)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// counts 返回以 positive 为正类时的 TP、FP、FN
+func counts(yTrue, yPred []int, positive int) (tp, fp, fn int) {
+	for i, y := range yTrue {
+		switch {
+		case yPred[i] == positive && y == positive:
+			tp++
+		case yPred[i] == positive:
+			fp++
+		case y == positive:
+			fn++
+		}
+	}
+	return tp, fp, fn
+}
+
+// classCounts 返回正类（1）和负类的样本数
+func classCounts(yTrue []int) (pos, neg int) {
+	for _, y := range yTrue {
+		if y == 1 {
+			pos++
+		} else {
+			neg++
+		}
+	}
+	return pos, neg
+}
+
+// thresholdGroup 得分等于 threshold 的样本中正类和负类的个数
+type thresholdGroup struct {
+	threshold float64
+	pos, neg  int
+}
+
+// thresholdGroups 按得分从高到低把样本分组，得分相同的样本放在同一组
+func thresholdGroups(yTrue []int, score []float64) []thresholdGroup {
+	order := make([]int, len(score))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return score[order[a]] > score[order[b]] })
+
+	var groups []thresholdGroup
+	for _, i := range order {
+		if len(groups) == 0 || groups[len(groups)-1].threshold != score[i] {
+			groups = append(groups, thresholdGroup{threshold: score[i]})
+		}
+		g := &groups[len(groups)-1]
+		if yTrue[i] == 1 {
+			g.pos++
+		} else {
+			g.neg++
+		}
+	}
+	return groups
+}
+
+func ratio(a, b int) float64 {
+	if b == 0 {
+		return 0
+	}
+	return float64(a) / float64(b)
+}
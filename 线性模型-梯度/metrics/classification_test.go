@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// 手算用例：3 个正类、3 个负类，得分 0.8 上有一个正类和一个负类并列
+var (
+	handTrue  = []int{1, 1, 0, 1, 0, 0}
+	handScore = []float64{0.9, 0.8, 0.8, 0.6, 0.4, 0.2}
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-12
+}
+
+func TestConfusionMatrix(t *testing.T) {
+	pred := Predict(handScore, 0.5) // [1 1 1 1 0 0]
+	cm := ConfusionMatrix(handTrue, pred, 2)
+	if want := [][]int{{2, 1}, {0, 3}}; !slices.Equal(cm[0], want[0]) || !slices.Equal(cm[1], want[1]) {
+		t.Errorf("混淆矩阵为 %v，应为 %v", cm, want)
+	}
+
+	tests := []struct {
+		name      string
+		got, want float64
+	}{
+		{"accuracy", Accuracy(handTrue, pred), 5.0 / 6},
+		{"precision", Precision(handTrue, pred, 1), 3.0 / 4},
+		{"recall", Recall(handTrue, pred, 1), 1},
+		{"F1", F1(handTrue, pred, 1), 6.0 / 7},
+		{"balanced accuracy", BalancedAccuracy(handTrue, pred), (1 + 2.0/3) / 2},
+		// 没有预测为正类的样本时精确率取 0
+		{"precision/no positive prediction", Precision(handTrue, make([]int, 6), 1), 0},
+	}
+	for _, tt := range tests {
+		if !near(tt.got, tt.want) {
+			t.Errorf("%s = %v，应为 %v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	// 多分类：第 i 行第 j 列是真实类别 i、预测为 j 的样本数
+	cm = ConfusionMatrix([]int{0, 1, 2, 2, 1}, []int{0, 2, 2, 1, 1}, 3)
+	if want := [][]int{{1, 0, 0}, {0, 1, 1}, {0, 1, 1}}; !slices.EqualFunc(cm, want, slices.Equal[[]int]) {
+		t.Errorf("三分类混淆矩阵为 %v，应为 %v", cm, want)
+	}
+}
+
+func TestROCAUC(t *testing.T) {
+	// 9 个正负样本对中正类得分更高的有 7 对，并列的 1 对记 0.5
+	if got := ROCAUC(handTrue, handScore); !near(got, 7.5/9) {
+		t.Errorf("ROC-AUC = %v，应为 %v", got, 7.5/9)
+	}
+
+	// 得分并列的样本在同一个阈值上一起计入，曲线上只有一个点
+	c := ROCCurve(handTrue, handScore)
+	wantX := []float64{0, 0, 1.0 / 3, 1.0 / 3, 2.0 / 3, 1}
+	wantY := []float64{0, 1.0 / 3, 2.0 / 3, 1, 1, 1}
+	if !slices.EqualFunc(c.X, wantX, near) || !slices.EqualFunc(c.Y, wantY, near) {
+		t.Errorf("ROC 曲线为 X=%v Y=%v，应为 X=%v Y=%v", c.X, c.Y, wantX, wantY)
+	}
+
+	// 召回率每次增加 1/3 时的精确率依次为 1、2/3、3/4
+	if got, want := PRAUC(handTrue, handScore), (1+2.0/3+3.0/4)/3; !near(got, want) {
+		t.Errorf("PR-AUC = %v，应为 %v", got, want)
+	}
+
+	// 所有得分相同时模型没有区分能力
+	if got := ROCAUC([]int{1, 0, 1, 0}, []float64{0.5, 0.5, 0.5, 0.5}); !near(got, 0.5) {
+		t.Errorf("得分全部相同时 ROC-AUC = %v，应为 0.5", got)
+	}
+}
+
+func TestSingleClass(t *testing.T) {
+	score := []float64{0.9, 0.4, 0.1}
+	if got := ROCAUC([]int{1, 1, 1}, score); !math.IsNaN(got) {
+		t.Errorf("只有正类时 ROC-AUC = %v，应为 NaN", got)
+	}
+	if got := ROCAUC([]int{0, 0, 0}, score); !math.IsNaN(got) {
+		t.Errorf("只有负类时 ROC-AUC = %v，应为 NaN", got)
+	}
+	if got := PRAUC([]int{0, 0, 0}, score); !math.IsNaN(got) {
+		t.Errorf("没有正类时 PR-AUC = %v，应为 NaN", got)
+	}
+	// 平衡准确率只统计出现过的类别
+	if got := BalancedAccuracy([]int{0, 0, 0}, []int{0, 1, 0}); !near(got, 2.0/3) {
+		t.Errorf("只有负类时平衡准确率 = %v，应为 2/3", got)
+	}
+	if got := Recall([]int{0, 0, 0}, []int{0, 1, 0}, 1); got != 0 {
+		t.Errorf("没有正类时召回率 = %v，应为 0", got)
+	}
+}
@@ -0,0 +1,144 @@
+// Package metrics 计算回归和分类模型的评估指标，定义与 scikit-learn 的 sklearn.metrics 一致。
+//
+// 单个指标都是接收真实值和预测值切片的普通函数；EvaluateRegression 和 EvaluateBinary
+// 一次算出全部指标，返回的报告可以按表格打印，也可以把 Summary 放进图像标题。
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// R2 决定系数 1 - SS_res/SS_tot；真实值全部相同时，预测完全正确返回 1，否则返回 0
+func R2(yTrue, yPred []float64) float64 {
+	mean := mean(yTrue)
+	ssRes, ssTot := 0.0, 0.0
+	for i, y := range yTrue {
+		ssRes += (y - yPred[i]) * (y - yPred[i])
+		ssTot += (y - mean) * (y - mean)
+	}
+	if ssTot == 0 {
+		if ssRes == 0 {
+			return 1
+		}
+		return 0
+	}
+	return 1 - ssRes/ssTot
+}
+
+// AdjustedR2 按样本数 n 和特征数 p 修正的决定系数，n <= p+1 时没有定义，返回 NaN
+func AdjustedR2(r2 float64, n, p int) float64 {
+	if n-p-1 <= 0 {
+		return math.NaN()
+	}
+	return 1 - (1-r2)*float64(n-1)/float64(n-p-1)
+}
+
+// MAE 平均绝对误差
+func MAE(yTrue, yPred []float64) float64 {
+	sum := 0.0
+	for i, y := range yTrue {
+		sum += math.Abs(y - yPred[i])
+	}
+	return sum / float64(len(yTrue))
+}
+
+// RMSE 均方根误差
+func RMSE(yTrue, yPred []float64) float64 {
+	sum := 0.0
+	for i, y := range yTrue {
+		sum += (y - yPred[i]) * (y - yPred[i])
+	}
+	return math.Sqrt(sum / float64(len(yTrue)))
+}
+
+// MAPE 平均绝对百分比误差（比例，不乘 100）；分母取 max(|y|, 机器精度)，
+// 与 scikit-learn 一样，真实值接近 0 时结果会非常大
+func MAPE(yTrue, yPred []float64) float64 {
+	const eps = 2.220446049250313e-16
+	sum := 0.0
+	for i, y := range yTrue {
+		sum += math.Abs(y-yPred[i]) / math.Max(math.Abs(y), eps)
+	}
+	return sum / float64(len(yTrue))
+}
+
+// ExplainedVariance 可解释方差 1 - Var(y - ŷ)/Var(y)，与 R2 的区别是不惩罚残差的整体偏移
+func ExplainedVariance(yTrue, yPred []float64) float64 {
+	residual := make([]float64, len(yTrue))
+	for i, y := range yTrue {
+		residual[i] = y - yPred[i]
+	}
+	varTrue, varRes := variance(yTrue), variance(residual)
+	if varTrue == 0 {
+		if varRes == 0 {
+			return 1
+		}
+		return 0
+	}
+	return 1 - varRes/varTrue
+}
+
+// RegressionReport 回归模型的全部评估指标
+type RegressionReport struct {
+	N, Features       int
+	R2                float64
+	AdjustedR2        float64
+	MAE               float64
+	RMSE              float64
+	MAPE              float64
+	ExplainedVariance float64
+}
+
+// EvaluateRegression 计算全部回归指标，numFeatures 是模型使用的特征数，用于修正 R²
+func EvaluateRegression(yTrue, yPred []float64, numFeatures int) RegressionReport {
+	r2 := R2(yTrue, yPred)
+	return RegressionReport{
+		N:                 len(yTrue),
+		Features:          numFeatures,
+		R2:                r2,
+		AdjustedR2:        AdjustedR2(r2, len(yTrue), numFeatures),
+		MAE:               MAE(yTrue, yPred),
+		RMSE:              RMSE(yTrue, yPred),
+		MAPE:              MAPE(yTrue, yPred),
+		ExplainedVariance: ExplainedVariance(yTrue, yPred),
+	}
+}
+
+// Summary 返回适合放在图像标题中的一行摘要
+func (r RegressionReport) Summary() string {
+	return fmt.Sprintf("R²=%.4f, RMSE=%.4g, MAE=%.4g", r.R2, r.RMSE, r.MAE)
+}
+
+func (r RegressionReport) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%-20s %12s\n", "metric", "value")
+	row := func(name string, v float64) { fmt.Fprintf(&sb, "%-20s %12.6g\n", name, v) }
+	row("R²", r.R2)
+	row(fmt.Sprintf("adjusted R² (p=%d)", r.Features), r.AdjustedR2)
+	row("MAE", r.MAE)
+	row("RMSE", r.RMSE)
+	row("MAPE", r.MAPE)
+	row("explained variance", r.ExplainedVariance)
+	fmt.Fprintf(&sb, "%-20s %12d\n", "samples", r.N)
+	return sb.String()
+}
+
+func mean(v []float64) float64 {
+	sum := 0.0
+	for _, x := range v {
+		sum += x
+	}
+	return sum / float64(len(v))
+}
+
+// variance 总体方差
+func variance(v []float64) float64 {
+	m := mean(v)
+	sum := 0.0
+	for _, x := range v {
+		sum += (x - m) * (x - m)
+	}
+	return sum / float64(len(v))
+}
@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ThresholdCriterion 选择判为正类的概率阈值时优化的指标
+type ThresholdCriterion string
+
+const (
+	MaxF1     ThresholdCriterion = "f1"     // F1 最大
+	MaxYouden ThresholdCriterion = "youden" // Youden's J = 真正率 - 假正率最大，即 ROC 曲线上离对角线最远的点
+	MinCost   ThresholdCriterion = "cost"   // 按代价矩阵计算的平均误分类代价最小
+)
+
+// ThresholdCriteria 返回全部阈值选择指标
+func ThresholdCriteria() []ThresholdCriterion {
+	return []ThresholdCriterion{MaxF1, MaxYouden, MinCost}
+}
+
+// CostMatrix 二分类的代价矩阵，第 i 行第 j 列是真实类别为 i、预测为 j 的单个样本的代价，
+// 与 ConfusionMatrix 的排列相同
+type CostMatrix [2][2]float64
+
+// DefaultCosts 判对没有代价、两种错误的代价都是 1，此时最小化代价等价于最大化准确率
+var DefaultCosts = CostMatrix{{0, 1}, {1, 0}}
+
+// ParseCostMatrix 解析按 "TN,FP,FN,TP" 顺序用逗号分隔的 4 个代价
+func ParseCostMatrix(s string) (CostMatrix, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 4 {
+		return CostMatrix{}, fmt.Errorf("metrics: 代价矩阵需要按 TN,FP,FN,TP 给出 4 个数，实际为 %q", s)
+	}
+	var c CostMatrix
+	for k, part := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return CostMatrix{}, fmt.Errorf("metrics: 代价矩阵中的 %q 不是数字", part)
+		}
+		c[k/2][k%2] = v
+	}
+	return c, nil
+}
+
+// Threshold 选出的阈值及其在给定数据上的指标值
+type Threshold struct {
+	Criterion ThresholdCriterion
+	Threshold float64 // 概率不小于该值时判为正类
+	Score     float64 // F1、Youden's J 或平均代价
+}
+
+func (t Threshold) String() string {
+	return fmt.Sprintf("阈值 %.6g（%s=%.6g）", t.Threshold, t.Criterion, t.Score)
+}
+
+// TuneThreshold 依次尝试 prob 中出现过的每个值作为阈值，返回使 criterion 最优的那一个；
+// 指标相同时取离 0.5 最近的阈值。costs 只在 MinCost 时使用。
+// 全部判为负类也作为候选，此时返回的阈值略大于最大的概率
+func TuneThreshold(yTrue []int, prob []float64, criterion ThresholdCriterion, costs CostMatrix) (Threshold, error) {
+	var score func(tp, fp, fn, tn int) float64
+	switch criterion {
+	case MaxF1:
+		score = func(tp, fp, fn, _ int) float64 { return ratio(2*tp, 2*tp+fp+fn) }
+	case MaxYouden:
+		score = func(tp, fp, fn, tn int) float64 { return ratio(tp, tp+fn) - ratio(fp, fp+tn) }
+	case MinCost:
+		// 取负值统一成越大越好
+		score = func(tp, fp, fn, tn int) float64 {
+			total := costs[0][0]*float64(tn) + costs[0][1]*float64(fp) + costs[1][0]*float64(fn) + costs[1][1]*float64(tp)
+			return -total / float64(tp+fp+fn+tn)
+		}
+	default:
+		return Threshold{}, fmt.Errorf("metrics: 未知的阈值选择指标 %q，可选：%v", criterion, ThresholdCriteria())
+	}
+
+	pos, neg := classCounts(yTrue)
+	groups := thresholdGroups(yTrue, prob)
+	best := Threshold{Criterion: criterion, Threshold: 0.5, Score: score(0, 0, pos, neg)}
+	if len(groups) > 0 {
+		best.Threshold = math.Nextafter(groups[0].threshold, math.Inf(1))
+	}
+	tp, fp := 0, 0
+	for _, g := range groups {
+		tp += g.pos
+		fp += g.neg
+		s := score(tp, fp, pos-tp, neg-fp)
+		if s > best.Score || (s == best.Score && math.Abs(g.threshold-0.5) < math.Abs(best.Threshold-0.5)) {
+			best.Threshold, best.Score = g.threshold, s
+		}
+	}
+	if criterion == MinCost {
+		best.Score = -best.Score
+	}
+	return best, nil
+}
@@ -0,0 +1,105 @@
+package metrics
+
+import "testing"
+
+func TestTuneThreshold(t *testing.T) {
+	// 手算：各阈值处 (TP, FP) 依次为 0.9:(1,0) 0.8:(2,1) 0.6:(3,1) 0.4:(3,2) 0.2:(3,3)
+	tests := []struct {
+		name      string
+		criterion ThresholdCriterion
+		costs     CostMatrix
+		threshold float64
+		score     float64
+	}{
+		{"f1", MaxF1, DefaultCosts, 0.6, 6.0 / 7},
+		{"youden", MaxYouden, DefaultCosts, 0.6, 1 - 1.0/3},
+		{"cost/default", MinCost, DefaultCosts, 0.6, 1.0 / 6},
+		// 假正例代价很高时只把得分最高的样本判为正类：2 个假负例，平均代价 2/6
+		{"cost/expensive FP", MinCost, CostMatrix{{0, 5}, {1, 0}}, 0.9, 2.0 / 6},
+		// 假负例代价很高时仍选 0.6：它已经没有假负例，且假正例最少
+		{"cost/expensive FN", MinCost, CostMatrix{{0, 1}, {10, 0}}, 0.6, 1.0 / 6},
+	}
+	for _, tt := range tests {
+		got, err := TuneThreshold(handTrue, handScore, tt.criterion, tt.costs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Threshold != tt.threshold || !near(got.Score, tt.score) {
+			t.Errorf("%s: 得到 %v，应为阈值 %v、指标 %v", tt.name, got, tt.threshold, tt.score)
+		}
+	}
+
+	if _, err := TuneThreshold(handTrue, handScore, "accuracy", DefaultCosts); err == nil {
+		t.Error("TuneThreshold 没有拒绝未知的指标")
+	}
+}
+
+func TestTuneThresholdTies(t *testing.T) {
+	// 阈值 0.9 和 0.4 的 Youden's J 都是 0.5，取离 0.5 更近的 0.4
+	got, err := TuneThreshold([]int{1, 0, 1, 0}, []float64{0.9, 0.7, 0.4, 0.1}, MaxYouden, DefaultCosts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Threshold != 0.4 || !near(got.Score, 0.5) {
+		t.Errorf("得到 %v，应为阈值 0.4、J=0.5", got)
+	}
+
+	// 得分并列的样本只能一起判为正类或负类：0.5 上的正类和负类不能分开
+	got, err = TuneThreshold([]int{1, 0, 1, 0}, []float64{0.5, 0.5, 0.8, 0.1}, MaxF1, DefaultCosts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Threshold != 0.5 || !near(got.Score, 0.8) {
+		t.Errorf("得到 %v，应为阈值 0.5、F1=0.8", got)
+	}
+}
+
+func TestTuneThresholdSingleClass(t *testing.T) {
+	// 没有正类时只有全部判为负类的 Youden's J 为 0，其余阈值都是负数，阈值取略大于最大的概率
+	prob := []float64{0.2, 0.7}
+	got, err := TuneThreshold([]int{0, 0}, prob, MaxYouden, DefaultCosts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Threshold <= 0.7 || got.Score != 0 {
+		t.Errorf("得到 %v，阈值应略大于 0.7、J=0", got)
+	}
+	for _, p := range Predict(prob, got.Threshold) {
+		if p != 0 {
+			t.Errorf("阈值 %v 仍把样本判为正类", got.Threshold)
+		}
+	}
+
+	// 没有正类时任何阈值的 F1 都是 0，按并列规则取离 0.5 最近的候选
+	got, err = TuneThreshold([]int{0, 0}, prob, MaxF1, DefaultCosts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Threshold != 0.7 || got.Score != 0 {
+		t.Errorf("得到 %v，应为阈值 0.7、F1=0", got)
+	}
+
+	// 只有正类时代价最小的是全部判为正类
+	got, err = TuneThreshold([]int{1, 1}, prob, MinCost, DefaultCosts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Threshold != 0.2 || got.Score != 0 {
+		t.Errorf("得到 %v，应为阈值 0.2、代价 0", got)
+	}
+}
+
+func TestParseCostMatrix(t *testing.T) {
+	c, err := ParseCostMatrix("0, 1, 5,0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (CostMatrix{{0, 1}, {5, 0}}); c != want {
+		t.Errorf("解析结果为 %v，应为 %v", c, want)
+	}
+	for _, s := range []string{"0,1,1", "0,1,x,0"} {
+		if _, err := ParseCostMatrix(s); err == nil {
+			t.Errorf("ParseCostMatrix 没有拒绝 %q", s)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+// Package model 把训练好的模型保存成带版本号的 JSON 文件，并能重新加载后用于预测。
+//
+// 一个文件描述一个模型：线性回归、逻辑回归、softmax 回归、k-means 或均值漂移。文件中除了参数本身，
+// 还记录训练时的超参数、特征缩放器的状态和训练数据的指纹，便于确认模型是用哪份数据、
+// 以什么设置训练出来的。Coef、Intercept、Weights、Intercepts 和 Centers 都定义在缩放后的特征空间中，
+// 预测时先用保存的缩放器变换输入，再套用参数。
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"ai/calibration"
+	"ai/scale"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// FormatVersion 当前的文件格式版本，增加会改变预测结果的字段时递增；
+// Load 拒绝读取比它更新的版本，以免旧程序忽略不认识的字段、给出不同的概率。
+//
+//	1：线性回归、逻辑回归、k-means 和均值漂移
+//	2：softmax 回归（Intercepts、Weights）、可以为 0 的 Threshold 和概率校准器 Calibration
+const FormatVersion = 2
+
+// Kind 模型种类
+type Kind string
+
+const (
+	Linear    Kind = "linear"    // y = Intercept + Coef·x
+	Logistic  Kind = "logistic"  // P(y=1) = σ(Intercept + Coef·x)
+	Softmax   Kind = "softmax"   // P(y=c) ∝ exp(Intercepts[c] + Weights[c]·x)
+	KMeans    Kind = "kmeans"    // 归入最近的 Centers[k]
+	MeanShift Kind = "meanshift" // Centers 是收敛后的模式点，归入最近的模式点
+)
+
+// Model 保存在磁盘上的模型
+type Model struct {
+	Version     int                    `json:"version"`
+	Kind        Kind                   `json:"kind"`
+	Features    []string               `json:"features"`         // 特征列名，预测时按名称从 CSV 中取列
+	Target      string                 `json:"target,omitempty"` // 目标列名，聚类模型为空
+	Intercept   float64                `json:"intercept,omitempty"`
+	Coef        []float64              `json:"coef,omitempty"`
+	Threshold   *float64               `json:"threshold,omitempty"`   // 逻辑回归判为正类的概率阈值，为 nil 时是 0.5
+	Calibration *Calibration           `json:"calibration,omitempty"` // 逻辑回归概率的校准器，为 nil 时不校准
+	Intercepts  []float64              `json:"intercepts,omitempty"`  // softmax 回归每个类别的偏置
+	Weights     [][]float64            `json:"weights,omitempty"`     // softmax 回归每个类别的权重
+	Centers     [][]float64            `json:"centers,omitempty"`
+	Scaler      *Scaler                `json:"scaler,omitempty"` // 为 nil 时不缩放
+	Hyperparams map[string]interface{} `json:"hyperparams,omitempty"`
+	Data        Fingerprint            `json:"data"`
+	Created     time.Time              `json:"created"`
+}
+
+// Scaler 特征缩放器的状态
+type Scaler struct {
+	Name   string    `json:"name"`
+	Center []float64 `json:"center"`
+	Scale  []float64 `json:"scale"`
+}
+
+// NewScaler 记录已拟合的缩放器 s 的状态
+func NewScaler(s scale.Scaler) *Scaler {
+	p := s.Params()
+	return &Scaler{Name: s.Name(), Center: p.Center, Scale: p.Scale}
+}
+
+// Restore 从保存的状态恢复缩放器
+func (s *Scaler) Restore() (scale.Scaler, error) {
+	return scale.Restore(s.Name, scale.Affine{Center: s.Center, Scale: s.Scale})
+}
+
+// Calibration 概率校准器的状态，Platt 缩放使用 A、B，保序回归使用节点 X、Y；
+// 校准器作用在决策值 Intercept + Coef·x 上，Threshold 比较的是校准后的概率
+type Calibration struct {
+	Method string    `json:"method"`
+	A      float64   `json:"a,omitempty"`
+	B      float64   `json:"b,omitempty"`
+	X      []float64 `json:"x,omitempty"`
+	Y      []float64 `json:"y,omitempty"`
+}
+
+// NewCalibration 记录已拟合的校准器 c 的状态，c 为 nil 时返回 nil
+func NewCalibration(c calibration.Calibrator) *Calibration {
+	switch c := c.(type) {
+	case *calibration.Platt:
+		return &Calibration{Method: c.Name(), A: c.A, B: c.B}
+	case *calibration.Isotonic:
+		return &Calibration{Method: c.Name(), X: c.X, Y: c.Y}
+	}
+	return nil
+}
+
+// Restore 从保存的状态恢复校准器
+func (c *Calibration) Restore() (calibration.Calibrator, error) {
+	switch c.Method {
+	case "platt":
+		return &calibration.Platt{A: c.A, B: c.B}, nil
+	case "isotonic":
+		if len(c.X) == 0 || len(c.X) != len(c.Y) {
+			return nil, fmt.Errorf("model: 保序回归有 %d 个节点横坐标、%d 个纵坐标", len(c.X), len(c.Y))
+		}
+		return &calibration.Isotonic{X: c.X, Y: c.Y}, nil
+	}
+	return nil, fmt.Errorf("model: 未知的校准方法 %q，可选：%v", c.Method, calibration.Names())
+}
+
+// Fingerprint 训练数据的指纹：样本数、特征数和全部数值的 SHA-256
+type Fingerprint struct {
+	Samples  int    `json:"samples"`
+	Features int    `json:"features"`
+	SHA256   string `json:"sha256"`
+}
+
+// NewFingerprint 按行依次对 X 的每个元素和（非 nil 时）y 的每个元素的 IEEE 754 表示求哈希，
+// 同一份数据无论来自合成还是 CSV 都得到相同的指纹
+func NewFingerprint(X mat.Matrix, y []float64) Fingerprint {
+	rows, cols := X.Dims()
+	h := sha256.New()
+	var buf [8]byte
+	write := func(v float64) {
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(v))
+		h.Write(buf[:])
+	}
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			write(X.At(i, j))
+		}
+		if y != nil {
+			write(y[i])
+		}
+	}
+	return Fingerprint{Samples: rows, Features: cols, SHA256: hex.EncodeToString(h.Sum(nil))}
+}
+
+// Save 把 m 写成缩进的 JSON 文件，未设置的 Version 和 Created 会被自动填上
+func Save(path string, m *Model) error {
+	if m.Version == 0 {
+		m.Version = FormatVersion
+	}
+	if m.Created.IsZero() {
+		m.Created = time.Now().UTC().Truncate(time.Second)
+	}
+	if err := m.Validate(); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Load 读取 Save 写出的模型文件并检查其完整性
+func Load(path string) (*Model, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var m Model
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if m.Version < 1 || m.Version > FormatVersion {
+		return nil, fmt.Errorf("%s: 不支持的模型格式版本 %d，当前程序支持 1 到 %d", path, m.Version, FormatVersion)
+	}
+	if err := m.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &m, nil
+}
+
+// Validate 检查参数的维数与特征数是否一致
+func (m *Model) Validate() error {
+	d := len(m.Features)
+	if d == 0 {
+		return fmt.Errorf("model: 没有特征列")
+	}
+	switch m.Kind {
+	case Linear, Logistic:
+		if len(m.Coef) != d {
+			return fmt.Errorf("model: %s 模型有 %d 个特征，但权重有 %d 个", m.Kind, d, len(m.Coef))
+		}
+	case Softmax:
+		if len(m.Weights) < 2 || len(m.Intercepts) != len(m.Weights) {
+			return fmt.Errorf("model: softmax 模型有 %d 个偏置、%d 组权重，至少需要 2 个类别", len(m.Intercepts), len(m.Weights))
+		}
+		for c, w := range m.Weights {
+			if len(w) != d {
+				return fmt.Errorf("model: 第 %d 个类别的权重有 %d 个，特征有 %d 个", c, len(w), d)
+			}
+		}
+	case KMeans, MeanShift:
+		if len(m.Centers) == 0 {
+			return fmt.Errorf("model: %s 模型没有中心点", m.Kind)
+		}
+		for k, c := range m.Centers {
+			if len(c) != d {
+				return fmt.Errorf("model: 第 %d 个中心点是 %d 维的，特征有 %d 个", k, len(c), d)
+			}
+		}
+	default:
+		return fmt.Errorf("model: 未知的模型种类 %q", m.Kind)
+	}
+	if m.Scaler != nil && (len(m.Scaler.Center) != d || len(m.Scaler.Scale) != d) {
+		return fmt.Errorf("model: 缩放器的维数与特征数 %d 不一致", d)
+	}
+	if m.Calibration != nil {
+		if m.Kind != Logistic {
+			return fmt.Errorf("model: 只有逻辑回归模型可以带校准器，当前为 %s", m.Kind)
+		}
+		if _, err := m.Calibration.Restore(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package model
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-12
+}
+
+func threshold(t float64) *float64 {
+	return &t
+}
+
+// roundTrip 把 m 保存到临时目录后重新加载
+func roundTrip(t *testing.T, m *Model) *Model {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "model.json")
+	if err := Save(path, m); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Version != FormatVersion || loaded.Kind != m.Kind || !loaded.Created.Equal(m.Created) || loaded.Data != m.Data {
+		t.Errorf("%s: 重新加载后为 version=%d kind=%s created=%v data=%v，应与保存时一致",
+			m.Kind, loaded.Version, loaded.Kind, loaded.Created, loaded.Data)
+	}
+	return loaded
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		name       string
+		model      *Model
+		X          *mat.Dense
+		wantValues []float64
+		wantLabels []int
+	}{
+		{
+			// 缩放后 (3,6) 变成 (1,1)：0.5 + 3 - 1 = 2.5
+			name: "linear",
+			model: &Model{
+				Kind: Linear, Features: []string{"a", "b"}, Target: "y",
+				Intercept: 0.5, Coef: []float64{3, -1},
+				Scaler: &Scaler{Name: "standard", Center: []float64{1, 2}, Scale: []float64{2, 4}},
+			},
+			X:          mat.NewDense(2, 2, []float64{3, 6, 1, 2}),
+			wantValues: []float64{2.5, 0.5},
+		},
+		{
+			// 决策值为 0 和 3，阈值 0.7 下只有第二个样本判为正类
+			name: "logistic",
+			model: &Model{
+				Kind: Logistic, Features: []string{"x"}, Target: "y",
+				Intercept: -1, Coef: []float64{2}, Threshold: threshold(0.7),
+			},
+			X:          mat.NewDense(2, 1, []float64{0.5, 2}),
+			wantValues: []float64{0.5, 1 / (1 + math.Exp(-3))},
+			wantLabels: []int{0, 1},
+		},
+		{
+			// 阈值 0 也能保存：所有样本都判为正类
+			name: "logistic/threshold 0",
+			model: &Model{
+				Kind: Logistic, Features: []string{"x"}, Target: "y",
+				Intercept: -1, Coef: []float64{2}, Threshold: threshold(0),
+			},
+			X:          mat.NewDense(2, 1, []float64{0.5, -20}),
+			wantValues: []float64{0.5, 1 / (1 + math.Exp(41))},
+			wantLabels: []int{1, 1},
+		},
+		{
+			// Platt 校准把决策值 0 映射为 σ(1·0 + 1)，超过阈值 0.7
+			name: "logistic/platt",
+			model: &Model{
+				Kind: Logistic, Features: []string{"x"}, Target: "y",
+				Intercept: -1, Coef: []float64{2}, Threshold: threshold(0.7),
+				Calibration: &Calibration{Method: "platt", A: 1, B: 1},
+			},
+			X:          mat.NewDense(2, 1, []float64{0.5, -1}),
+			wantValues: []float64{1 / (1 + math.Exp(-1)), 1 / (1 + math.Exp(2))},
+			wantLabels: []int{1, 0},
+		},
+		{
+			name: "kmeans",
+			model: &Model{
+				Kind: KMeans, Features: []string{"a", "b"},
+				Centers: [][]float64{{0, 0}, {5, 5}},
+			},
+			X:          mat.NewDense(3, 2, []float64{1, 1, 4, 6, -2, 0}),
+			wantLabels: []int{0, 1, 0},
+		},
+		{
+			// 中心点定义在缩放后的空间：(10,20) 缩放后是 (2,2)
+			name: "meanshift",
+			model: &Model{
+				Kind: MeanShift, Features: []string{"a", "b"},
+				Centers: [][]float64{{0, 0}, {2, 2}, {-3, 1}},
+				Scaler:  &Scaler{Name: "minmax", Center: []float64{0, 0}, Scale: []float64{5, 10}},
+			},
+			X:          mat.NewDense(3, 2, []float64{10, 20, 1, 1, -15, 10}),
+			wantLabels: []int{1, 0, 2},
+		},
+	}
+	for _, tt := range tests {
+		tt.model.Data = NewFingerprint(tt.X, nil)
+		tt.model.Hyperparams = map[string]interface{}{"seed": 1}
+		loaded := roundTrip(t, tt.model)
+		for _, m := range []*Model{tt.model, loaded} {
+			p, err := m.Predict(tt.X)
+			if err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+			if !slices.EqualFunc(p.Values, tt.wantValues, near) || !slices.Equal(p.Labels, tt.wantLabels) {
+				t.Errorf("%s: 预测值 %v、标签 %v，应为 %v、%v", tt.name, p.Values, p.Labels, tt.wantValues, tt.wantLabels)
+			}
+		}
+	}
+}
+
+// writeJSON 把修改过的模型文件写到临时目录，返回路径
+func writeJSON(t *testing.T, v interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "model.json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadRejects(t *testing.T) {
+	valid := Model{Version: FormatVersion, Kind: Linear, Features: []string{"x"}, Coef: []float64{1}}
+	tests := []struct {
+		name string
+		edit func(m *Model)
+		want string
+	}{
+		{"newer version", func(m *Model) { m.Version = FormatVersion + 1 }, "不支持的模型格式版本"},
+		{"missing version", func(m *Model) { m.Version = 0 }, "不支持的模型格式版本"},
+		{"unknown kind", func(m *Model) { m.Kind = "svm" }, "未知的模型种类"},
+		{"coef mismatch", func(m *Model) { m.Coef = []float64{1, 2} }, "权重有 2 个"},
+	}
+	for _, tt := range tests {
+		m := valid
+		tt.edit(&m)
+		_, err := Load(writeJSON(t, m))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: Load 返回 %v，应包含 %q", tt.name, err, tt.want)
+		}
+	}
+
+	// 旧版本的文件仍然可以读取
+	old := valid
+	old.Version = 1
+	if _, err := Load(writeJSON(t, old)); err != nil {
+		t.Errorf("Load 拒绝了版本 1 的文件：%v", err)
+	}
+
+	// Save 在写文件前同样检查
+	bad := valid
+	bad.Kind = "svm"
+	if err := Save(filepath.Join(t.TempDir(), "model.json"), &bad); err == nil {
+		t.Error("Save 没有拒绝未知的模型种类")
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	X := mat.NewDense(3, 2, []float64{1, 2, 3, 4, 5, 6})
+	y := []float64{0, 1, 0}
+	fp := NewFingerprint(X, y)
+	if fp.Samples != 3 || fp.Features != 2 || len(fp.SHA256) != 64 {
+		t.Errorf("指纹为 %+v，应有 3 个样本、2 个特征和 64 位十六进制哈希", fp)
+	}
+
+	// 相同的数值得到相同的指纹，与矩阵的存储方式无关
+	same := mat.DenseCopyOf(X.T().T())
+	if got := NewFingerprint(same, slices.Clone(y)); got != fp {
+		t.Errorf("相同数据的指纹不一致：%v 与 %v", got, fp)
+	}
+
+	changed := mat.DenseCopyOf(X)
+	changed.Set(2, 1, 6.000001)
+	for name, got := range map[string]Fingerprint{
+		"X 改变": NewFingerprint(changed, y),
+		"y 改变": NewFingerprint(X, []float64{0, 1, 1}),
+		"没有 y": NewFingerprint(X, nil),
+	} {
+		if got.SHA256 == fp.SHA256 {
+			t.Errorf("%s后指纹没有变化", name)
+		}
+	}
+}
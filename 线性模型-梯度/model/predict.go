@@ -0,0 +1,92 @@
+package model
+
+import (
+	"math"
+
+	"ai/calibration"
+	"ai/logreg"
+	"ai/softmax"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Prediction 对一批样本的预测结果
+type Prediction struct {
+	Values []float64   // 线性回归的预测值；逻辑回归的正类概率（带校准器时是校准后的概率）；softmax 回归预测类别的概率
+	Labels []int       // 逻辑回归的 0/1 标签、softmax 回归的类别或聚类编号，线性回归为 nil
+	Proba  [][]float64 // softmax 回归每个样本各类别的概率，其他模型为 nil
+}
+
+// Predict 对 X（列顺序与 m.Features 一致、原始单位）的每一行做预测
+func (m *Model) Predict(X mat.Matrix) (Prediction, error) {
+	if m.Scaler != nil {
+		s, err := m.Scaler.Restore()
+		if err != nil {
+			return Prediction{}, err
+		}
+		X = s.Transform(X)
+	}
+	var calibrator calibration.Calibrator
+	if m.Calibration != nil {
+		c, err := m.Calibration.Restore()
+		if err != nil {
+			return Prediction{}, err
+		}
+		calibrator = c
+	}
+	rows, _ := X.Dims()
+	var p Prediction
+	row := make([]float64, len(m.Features))
+	for i := 0; i < rows; i++ {
+		mat.Row(row, i, X)
+		switch m.Kind {
+		case Linear:
+			p.Values = append(p.Values, m.Intercept+floats.Dot(m.Coef, row))
+		case Logistic:
+			g := m.Intercept + floats.Dot(m.Coef, row)
+			prob := logreg.Sigmoid(g)
+			if calibrator != nil {
+				prob = calibrator.Transform([]float64{g})[0]
+			}
+			label := 0
+			if prob >= m.DecisionThreshold() {
+				label = 1
+			}
+			p.Values = append(p.Values, prob)
+			p.Labels = append(p.Labels, label)
+		case Softmax:
+			z := make([]float64, len(m.Weights))
+			for c, w := range m.Weights {
+				z[c] = m.Intercepts[c] + floats.Dot(w, row)
+			}
+			softmax.Softmax(z)
+			label := floats.MaxIdx(z)
+			p.Values = append(p.Values, z[label])
+			p.Labels = append(p.Labels, label)
+			p.Proba = append(p.Proba, z)
+		case KMeans, MeanShift:
+			p.Labels = append(p.Labels, m.nearest(row))
+		}
+	}
+	return p, nil
+}
+
+// DecisionThreshold 返回逻辑回归判为正类的概率阈值，没有保存阈值时为 0.5
+func (m *Model) DecisionThreshold() float64 {
+	if m.Threshold == nil {
+		return 0.5
+	}
+	return *m.Threshold
+}
+
+// nearest 返回离 x 最近的中心点编号
+func (m *Model) nearest(x []float64) int {
+	best, bestDist := 0, math.Inf(1)
+	for k, c := range m.Centers {
+		if d := floats.Distance(x, c, 2); d < bestDist {
+			best, bestDist = k, d
+		}
+	}
+	return best
+}
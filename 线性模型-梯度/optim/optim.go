@@ -0,0 +1,207 @@
+// Package optim 提供梯度下降使用的参数更新规则（优化器）。
+//
+// 所有训练循环都把参数和梯度拼成 []float64，再交给 Optimizer.Step 原地更新，
+// 这样线性回归和逻辑回归可以在运行时切换不同的优化器。
+package optim
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Optimizer 根据梯度更新参数
+type Optimizer interface {
+	// Step 使用学习率 lr 和梯度 grads 原地更新 params，两者长度必须一致
+	Step(params, grads []float64, lr float64)
+	// Reset 清空内部状态（动量、累积梯度等），以便重新训练
+	Reset()
+}
+
+// 各优化器的默认超参数
+const (
+	DefaultMomentum = 0.9
+	DefaultRho      = 0.9
+	DefaultBeta1    = 0.9
+	DefaultBeta2    = 0.999
+	DefaultEpsilon  = 1e-8
+)
+
+var constructors = map[string]func() Optimizer{
+	"sgd":      func() Optimizer { return NewSGD() },
+	"momentum": func() Optimizer { return NewMomentum(DefaultMomentum) },
+	"nesterov": func() Optimizer { return NewNesterov(DefaultMomentum) },
+	"adagrad":  func() Optimizer { return NewAdaGrad(DefaultEpsilon) },
+	"rmsprop":  func() Optimizer { return NewRMSProp(DefaultRho, DefaultEpsilon) },
+	"adam":     func() Optimizer { return NewAdam(DefaultBeta1, DefaultBeta2, DefaultEpsilon) },
+}
+
+// New 按名称创建使用默认超参数的优化器，名称见 Names
+func New(name string) (Optimizer, error) {
+	ctor, ok := constructors[name]
+	if !ok {
+		return nil, fmt.Errorf("optim: 未知的优化器 %q，可选：%v", name, Names())
+	}
+	return ctor(), nil
+}
+
+// Names 返回 New 支持的全部优化器名称
+func Names() []string {
+	names := make([]string, 0, len(constructors))
+	for name := range constructors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SGD 普通梯度下降：param -= lr * grad
+type SGD struct{}
+
+// NewSGD 创建普通梯度下降优化器
+func NewSGD() *SGD {
+	return &SGD{}
+}
+
+func (o *SGD) Step(params, grads []float64, lr float64) {
+	for i, g := range grads {
+		params[i] -= lr * g
+	}
+}
+
+func (o *SGD) Reset() {}
+
+// Momentum 动量法：v = beta*v + grad，param -= lr * v
+type Momentum struct {
+	Beta     float64
+	velocity []float64
+}
+
+// NewMomentum 创建动量优化器，beta 是动量系数（通常取 0.9）
+func NewMomentum(beta float64) *Momentum {
+	return &Momentum{Beta: beta}
+}
+
+func (o *Momentum) Step(params, grads []float64, lr float64) {
+	o.velocity = ensure(o.velocity, len(params))
+	for i, g := range grads {
+		o.velocity[i] = o.Beta*o.velocity[i] + g
+		params[i] -= lr * o.velocity[i]
+	}
+}
+
+func (o *Momentum) Reset() {
+	o.velocity = nil
+}
+
+// Nesterov Nesterov 加速梯度：v = beta*v + grad，param -= lr * (grad + beta*v)
+// 这是不需要在“前瞻点”重新求梯度的等价写法
+type Nesterov struct {
+	Beta     float64
+	velocity []float64
+}
+
+// NewNesterov 创建 Nesterov 优化器，beta 是动量系数（通常取 0.9）
+func NewNesterov(beta float64) *Nesterov {
+	return &Nesterov{Beta: beta}
+}
+
+func (o *Nesterov) Step(params, grads []float64, lr float64) {
+	o.velocity = ensure(o.velocity, len(params))
+	for i, g := range grads {
+		o.velocity[i] = o.Beta*o.velocity[i] + g
+		params[i] -= lr * (g + o.Beta*o.velocity[i])
+	}
+}
+
+func (o *Nesterov) Reset() {
+	o.velocity = nil
+}
+
+// AdaGrad 累积历史梯度平方，为每个参数自适应缩放学习率
+type AdaGrad struct {
+	Epsilon float64
+	sumSq   []float64
+}
+
+// NewAdaGrad 创建 AdaGrad 优化器，epsilon 用于避免除零
+func NewAdaGrad(epsilon float64) *AdaGrad {
+	return &AdaGrad{Epsilon: epsilon}
+}
+
+func (o *AdaGrad) Step(params, grads []float64, lr float64) {
+	o.sumSq = ensure(o.sumSq, len(params))
+	for i, g := range grads {
+		o.sumSq[i] += g * g
+		params[i] -= lr * g / (math.Sqrt(o.sumSq[i]) + o.Epsilon)
+	}
+}
+
+func (o *AdaGrad) Reset() {
+	o.sumSq = nil
+}
+
+// RMSProp 用梯度平方的指数移动平均缩放学习率
+type RMSProp struct {
+	Rho     float64
+	Epsilon float64
+	meanSq  []float64
+}
+
+// NewRMSProp 创建 RMSProp 优化器，rho 是移动平均的衰减系数
+func NewRMSProp(rho, epsilon float64) *RMSProp {
+	return &RMSProp{Rho: rho, Epsilon: epsilon}
+}
+
+func (o *RMSProp) Step(params, grads []float64, lr float64) {
+	o.meanSq = ensure(o.meanSq, len(params))
+	for i, g := range grads {
+		o.meanSq[i] = o.Rho*o.meanSq[i] + (1-o.Rho)*g*g
+		params[i] -= lr * g / (math.Sqrt(o.meanSq[i]) + o.Epsilon)
+	}
+}
+
+func (o *RMSProp) Reset() {
+	o.meanSq = nil
+}
+
+// Adam 结合一阶矩（动量）和二阶矩（RMSProp），并做偏差修正
+type Adam struct {
+	Beta1   float64
+	Beta2   float64
+	Epsilon float64
+	m, v    []float64
+	t       int
+}
+
+// NewAdam 创建 Adam 优化器
+func NewAdam(beta1, beta2, epsilon float64) *Adam {
+	return &Adam{Beta1: beta1, Beta2: beta2, Epsilon: epsilon}
+}
+
+func (o *Adam) Step(params, grads []float64, lr float64) {
+	o.m = ensure(o.m, len(params))
+	o.v = ensure(o.v, len(params))
+	o.t++
+	c1 := 1 - math.Pow(o.Beta1, float64(o.t))
+	c2 := 1 - math.Pow(o.Beta2, float64(o.t))
+	for i, g := range grads {
+		o.m[i] = o.Beta1*o.m[i] + (1-o.Beta1)*g
+		o.v[i] = o.Beta2*o.v[i] + (1-o.Beta2)*g*g
+		mHat := o.m[i] / c1
+		vHat := o.v[i] / c2
+		params[i] -= lr * mHat / (math.Sqrt(vHat) + o.Epsilon)
+	}
+}
+
+func (o *Adam) Reset() {
+	o.m, o.v, o.t = nil, nil, 0
+}
+
+// ensure 在首次调用或参数个数变化时分配长度为 n 的状态切片
+func ensure(state []float64, n int) []float64 {
+	if len(state) != n {
+		return make([]float64, n)
+	}
+	return state
+}
@@ -0,0 +1,144 @@
+package optim
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Schedule 学习率调度：根据已经执行的更新步数给出当前学习率
+type Schedule interface {
+	Rate(step int) float64
+}
+
+// Constant 固定学习率
+type Constant struct {
+	LR float64
+}
+
+func (s Constant) Rate(step int) float64 {
+	return s.LR
+}
+
+// StepDecay 阶梯衰减：每 Every 步把学习率乘以 Drop
+type StepDecay struct {
+	LR    float64
+	Drop  float64
+	Every int
+}
+
+func (s StepDecay) Rate(step int) float64 {
+	return s.LR * math.Pow(s.Drop, float64(step/s.Every))
+}
+
+// ExponentialDecay 指数衰减：lr * Decay^(step/DecaySteps)
+type ExponentialDecay struct {
+	LR         float64
+	Decay      float64
+	DecaySteps int
+}
+
+func (s ExponentialDecay) Rate(step int) float64 {
+	return s.LR * math.Pow(s.Decay, float64(step)/float64(s.DecaySteps))
+}
+
+// InverseTime 反时间衰减：lr / (1 + Decay*step)
+type InverseTime struct {
+	LR    float64
+	Decay float64
+}
+
+func (s InverseTime) Rate(step int) float64 {
+	return s.LR / (1 + s.Decay*float64(step))
+}
+
+// CosineRestarts 带热重启的余弦退火（SGDR）：
+// 每个周期内学习率从 LR 按余弦曲线降到 MinLR，然后重新开始，
+// 第一个周期长 Period 步，之后每个周期的长度乘以 Mult
+type CosineRestarts struct {
+	LR     float64
+	MinLR  float64
+	Period int
+	Mult   float64
+}
+
+func (s CosineRestarts) Rate(step int) float64 {
+	period := float64(s.Period)
+	t := float64(step)
+	// 找到 step 所在的周期以及在该周期内的位置
+	for t >= period {
+		t -= period
+		if s.Mult > 1 {
+			period *= s.Mult
+		}
+	}
+	return s.MinLR + (s.LR-s.MinLR)*(1+math.Cos(math.Pi*t/period))/2
+}
+
+// Warmup 线性预热：前 Steps 步学习率从 0 线性增加到 After 的初始学习率，
+// 之后交给 After 调度（After 的步数从预热结束时重新计）
+type Warmup struct {
+	Steps int
+	After Schedule
+}
+
+func (s Warmup) Rate(step int) float64 {
+	if step < s.Steps {
+		return s.After.Rate(0) * float64(step+1) / float64(s.Steps)
+	}
+	return s.After.Rate(step - s.Steps)
+}
+
+var scheduleConstructors = map[string]func(lr float64, totalSteps int) Schedule{
+	"constant": func(lr float64, totalSteps int) Schedule {
+		return Constant{LR: lr}
+	},
+	// 每四分之一训练过程学习率减半
+	"step": func(lr float64, totalSteps int) Schedule {
+		return StepDecay{LR: lr, Drop: 0.5, Every: atLeastOne(totalSteps / 4)}
+	},
+	// 训练结束时衰减到初始学习率的 1%
+	"exp": func(lr float64, totalSteps int) Schedule {
+		return ExponentialDecay{LR: lr, Decay: 0.01, DecaySteps: atLeastOne(totalSteps)}
+	},
+	// 训练结束时衰减到初始学习率的 10%
+	"inverse": func(lr float64, totalSteps int) Schedule {
+		return InverseTime{LR: lr, Decay: 9 / float64(atLeastOne(totalSteps))}
+	},
+	// 周期依次为 T、2T、4T，三个周期正好覆盖整个训练过程
+	"cosine": func(lr float64, totalSteps int) Schedule {
+		return CosineRestarts{LR: lr, Period: atLeastOne(totalSteps / 7), Mult: 2}
+	},
+}
+
+// NewSchedule 按名称创建学习率调度，lr 是初始学习率，
+// totalSteps 是预计的总更新步数，用于推算默认的衰减速度；
+// warmupSteps 大于 0 时在前面加上线性预热
+func NewSchedule(name string, lr float64, totalSteps, warmupSteps int) (Schedule, error) {
+	ctor, ok := scheduleConstructors[name]
+	if !ok {
+		return nil, fmt.Errorf("optim: 未知的学习率调度 %q，可选：%v", name, ScheduleNames())
+	}
+	s := ctor(lr, totalSteps-warmupSteps)
+	if warmupSteps > 0 {
+		s = Warmup{Steps: warmupSteps, After: s}
+	}
+	return s, nil
+}
+
+// ScheduleNames 返回 NewSchedule 支持的全部调度名称
+func ScheduleNames() []string {
+	names := make([]string, 0, len(scheduleConstructors))
+	for name := range scheduleConstructors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func atLeastOne(n int) int {
+	if n < 1 {
+		return 1
+	}
+	return n
+}
@@ -0,0 +1,47 @@
+// Package rng 统一管理随机数种子。
+//
+// 各个演示程序通过 -seed 参数拿到同一个种子，再由本包派生出 math/rand 的 *rand.Rand
+// 和 gonum distuv、pa-m/sklearn 使用的 golang.org/x/exp/rand.Source，
+// 使数据生成、样本打乱、聚类初始化等全部随机过程都可以复现。
+// 种子为 0 时按当前时间随机选取，实际使用的种子会打印在运行输出中。
+package rng
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	exprand "golang.org/x/exp/rand"
+)
+
+// FlagUsage 是各程序 -seed 参数的统一说明
+const FlagUsage = "随机种子，控制数据生成、样本打乱和聚类初始化，0 表示按当前时间随机选取"
+
+// Resolve 返回实际使用的种子：seed 为 0 时按当前时间生成一个非 0 的种子，否则原样返回
+func Resolve(seed int64) int64 {
+	for seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return seed
+}
+
+// Setup 解析种子并把它打印到运行输出，返回实际使用的种子；
+// 同时为 golang.org/x/exp/rand 的全局随机源设置种子，
+// 使只能使用全局随机源的第三方代码（如 pa-m/sklearn 的 SGD 初始化）也可以复现
+func Setup(seed int64) int64 {
+	seed = Resolve(seed)
+	exprand.Seed(uint64(seed))
+	fmt.Printf("Seed: %d\n", seed)
+	return seed
+}
+
+// New 返回以 seed 为种子的 math/rand 随机数生成器
+func New(seed int64) *rand.Rand {
+	return rand.New(rand.NewSource(seed))
+}
+
+// Source 返回以 seed 为种子的 golang.org/x/exp/rand 随机源，
+// 可以赋给 distuv 分布的 Src 字段或 pa-m/sklearn 模型的 RandomState
+func Source(seed int64) exprand.Source {
+	return exprand.NewSource(uint64(seed))
+}
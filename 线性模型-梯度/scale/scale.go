@@ -0,0 +1,193 @@
+// Package scale 提供特征缩放：StandardScaler、MinMaxScaler 和 RobustScaler，
+// 接口仿照 scikit-learn 的 sklearn.preprocessing。
+//
+// 三种缩放器拟合后都是逐列的仿射变换 x' = (x - Center[j]) / Scale[j]，因此在缩放后的
+// 特征上训练出的线性模型 b' + w'·x' 可以用 UnscaleCoef 精确换算回原始单位的 b、w，
+// 既能用较大的学习率快速收敛，又能直接和生成数据所用的真实参数对比。
+package scale
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Scaler 特征缩放器
+type Scaler interface {
+	// Fit 按 X 的每一列估计缩放参数
+	Fit(X mat.Matrix)
+	// Transform 返回缩放后的新矩阵，X 不会被修改
+	Transform(X mat.Matrix) *mat.Dense
+	// InverseTransform 把缩放后的矩阵还原成原始单位
+	InverseTransform(X mat.Matrix) *mat.Dense
+	// UnscaleCoef 把在缩放后特征上训练得到的偏置和权重换算回原始单位
+	UnscaleCoef(b float64, w []float64) (float64, []float64)
+	// ScaleCoef 是 UnscaleCoef 的逆变换，把原始单位的参数换算到缩放后的特征上
+	ScaleCoef(b float64, w []float64) (float64, []float64)
+	// Name 返回 New 中使用的名称
+	Name() string
+	// Params 返回拟合得到的仿射参数，SetParams 用保存的参数恢复缩放器而不必重新拟合
+	Params() Affine
+	SetParams(p Affine)
+}
+
+// Affine 逐列的仿射变换 x' = (x - Center[j]) / Scale[j]，是各缩放器拟合后的状态
+type Affine struct {
+	Center []float64
+	Scale  []float64
+}
+
+func (a Affine) Transform(X mat.Matrix) *mat.Dense {
+	rows, cols := X.Dims()
+	out := mat.NewDense(rows, cols, nil)
+	out.Apply(func(_, j int, v float64) float64 { return (v - a.Center[j]) / a.Scale[j] }, X)
+	return out
+}
+
+func (a Affine) InverseTransform(X mat.Matrix) *mat.Dense {
+	rows, cols := X.Dims()
+	out := mat.NewDense(rows, cols, nil)
+	out.Apply(func(_, j int, v float64) float64 { return v*a.Scale[j] + a.Center[j] }, X)
+	return out
+}
+
+func (a Affine) Params() Affine { return a }
+
+func (a *Affine) SetParams(p Affine) { *a = p }
+
+// UnscaleCoef b' + Σ w'_j (x_j - c_j)/s_j = (b' - Σ w'_j c_j/s_j) + Σ (w'_j/s_j) x_j
+func (a Affine) UnscaleCoef(b float64, w []float64) (float64, []float64) {
+	orig := make([]float64, len(w))
+	for j := range w {
+		orig[j] = w[j] / a.Scale[j]
+		b -= orig[j] * a.Center[j]
+	}
+	return b, orig
+}
+
+func (a Affine) ScaleCoef(b float64, w []float64) (float64, []float64) {
+	scaled := make([]float64, len(w))
+	for j := range w {
+		scaled[j] = w[j] * a.Scale[j]
+		b += w[j] * a.Center[j]
+	}
+	return b, scaled
+}
+
+// fit 对每一列调用 stats 得到中心和尺度；尺度为 0 的常数列按 1 处理，只做平移
+func (a *Affine) fit(X mat.Matrix, stats func(col []float64) (center, scale float64)) {
+	_, cols := X.Dims()
+	a.Center = make([]float64, cols)
+	a.Scale = make([]float64, cols)
+	for j := 0; j < cols; j++ {
+		a.Center[j], a.Scale[j] = stats(mat.Col(nil, j, X))
+		if a.Scale[j] == 0 || math.IsNaN(a.Scale[j]) {
+			a.Scale[j] = 1
+		}
+	}
+}
+
+// None 不缩放，Center 为 0、Scale 为 1，便于调用方统一按缩放后的特征训练
+type None struct{ Affine }
+
+func (*None) Name() string { return "none" }
+
+func (s *None) Fit(X mat.Matrix) {
+	s.fit(X, func([]float64) (float64, float64) { return 0, 1 })
+}
+
+// StandardScaler 减去均值再除以（总体）标准差，使每列均值为 0、方差为 1
+type StandardScaler struct{ Affine }
+
+func (*StandardScaler) Name() string { return "standard" }
+
+func (s *StandardScaler) Fit(X mat.Matrix) {
+	s.fit(X, func(col []float64) (float64, float64) {
+		mean := 0.0
+		for _, v := range col {
+			mean += v
+		}
+		mean /= float64(len(col))
+		variance := 0.0
+		for _, v := range col {
+			variance += (v - mean) * (v - mean)
+		}
+		return mean, math.Sqrt(variance / float64(len(col)))
+	})
+}
+
+// MinMaxScaler 把每列线性映射到 [0, 1]
+type MinMaxScaler struct{ Affine }
+
+func (*MinMaxScaler) Name() string { return "minmax" }
+
+func (s *MinMaxScaler) Fit(X mat.Matrix) {
+	s.fit(X, func(col []float64) (float64, float64) {
+		lo, hi := col[0], col[0]
+		for _, v := range col {
+			lo, hi = math.Min(lo, v), math.Max(hi, v)
+		}
+		return lo, hi - lo
+	})
+}
+
+// RobustScaler 减去中位数再除以四分位距（第 75 与第 25 百分位数之差），不受离群点影响
+type RobustScaler struct{ Affine }
+
+func (*RobustScaler) Name() string { return "robust" }
+
+func (s *RobustScaler) Fit(X mat.Matrix) {
+	s.fit(X, func(col []float64) (float64, float64) {
+		sort.Float64s(col)
+		return quantile(col, 0.5), quantile(col, 0.75) - quantile(col, 0.25)
+	})
+}
+
+// quantile 在已排序的 sorted 上按线性插值计算 q 分位数（与 numpy 的默认方式一致）
+func quantile(sorted []float64, q float64) float64 {
+	pos := q * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	if lo+1 >= len(sorted) {
+		return sorted[lo]
+	}
+	frac := pos - float64(lo)
+	return sorted[lo]*(1-frac) + sorted[lo+1]*frac
+}
+
+// FitTransform 用 X 拟合 s 并返回缩放后的 X
+func FitTransform(s Scaler, X mat.Matrix) *mat.Dense {
+	s.Fit(X)
+	return s.Transform(X)
+}
+
+// Restore 按名称和保存的参数恢复一个已拟合的缩放器
+func Restore(name string, p Affine) (Scaler, error) {
+	s, err := New(name)
+	if err != nil {
+		return nil, err
+	}
+	s.SetParams(p)
+	return s, nil
+}
+
+// Names 返回 New 支持的缩放方式
+func Names() []string {
+	return []string{"none", "standard", "minmax", "robust"}
+}
+
+// New 按名称创建缩放器，供命令行选择，空字符串等同于 none
+func New(name string) (Scaler, error) {
+	switch name {
+	case "", "none":
+		return &None{}, nil
+	case "standard":
+		return &StandardScaler{}, nil
+	case "minmax":
+		return &MinMaxScaler{}, nil
+	case "robust":
+		return &RobustScaler{}, nil
+	}
+	return nil, fmt.Errorf("scale: 未知的缩放方式 %q，可选：%v", name, Names())
+}
@@ -0,0 +1,254 @@
+// Package softmax 实现多分类（multinomial）逻辑回归，即 softmax 回归：
+//
+//	P(y=c | x) = exp(b_c + w_c·x) / Σ_j exp(b_j + w_j·x)
+//
+// k 个类别各有一个偏置 b_c 和一个权重向量 w_c，权重排成 k×d 的矩阵 W，第 c 行是 w_c。
+// 交给 train 训练时参数排成 [b_0, ..., b_{k-1}, W 按行展开]，见 Pack 和 Unpack。
+// softmax 和交叉熵都通过 log-sum-exp 计算，线性部分很大时也不会上溢。
+package softmax
+
+import (
+	"fmt"
+	"math"
+
+	"ai/train"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// LogSumExp 计算 log Σ exp(z_j)，先减去最大值再求指数，避免上溢
+func LogSumExp(z []float64) float64 {
+	m := floats.Max(z)
+	if math.IsInf(m, 0) {
+		return m
+	}
+	sum := 0.0
+	for _, v := range z {
+		sum += math.Exp(v - m)
+	}
+	return m + math.Log(sum)
+}
+
+// Softmax 原地把 z 变成 exp(z_j) / Σ exp(z_j)
+func Softmax(z []float64) {
+	lse := LogSumExp(z)
+	for j, v := range z {
+		z[j] = math.Exp(v - lse)
+	}
+}
+
+// Pack 把偏置 b（长度 k）和权重矩阵 W（k×d）排成 train 使用的参数向量
+func Pack(b []float64, W mat.Matrix) []float64 {
+	k, d := W.Dims()
+	if len(b) != k {
+		panic(fmt.Sprintf("softmax: 偏置有 %d 个，权重矩阵有 %d 行", len(b), k))
+	}
+	params := make([]float64, k+k*d)
+	copy(params, b)
+	for c := 0; c < k; c++ {
+		mat.Row(params[k+c*d:k+(c+1)*d], c, W)
+	}
+	return params
+}
+
+// Unpack 把参数向量拆成偏置和权重矩阵，两者与 params 共享底层数组
+func Unpack(params []float64, k int) ([]float64, *mat.Dense) {
+	d := (len(params) - k) / k
+	if k < 1 || k+k*d != len(params) {
+		panic(fmt.Sprintf("softmax: %d 个参数不能拆成 %d 个类别", len(params), k))
+	}
+	return params[:k], mat.NewDense(k, d, params[k:])
+}
+
+// Decision 计算每个样本对每个类别的线性部分 Z = X·Wᵀ + b，结果是 n×k 矩阵
+// b: 每个类别的偏置
+// W: k×d 权重矩阵，d 等于 X 的列数
+// X: 特征矩阵，每行是一个样本
+func Decision(b []float64, W, X mat.Matrix) *mat.Dense {
+	n, d := X.Dims()
+	k, wd := W.Dims()
+	if wd != d || len(b) != k {
+		panic(fmt.Sprintf("softmax: 权重矩阵是 %d×%d 的、偏置有 %d 个，与 %d 个特征不一致", k, wd, len(b), d))
+	}
+	Z := mat.NewDense(n, k, nil)
+	Z.Mul(X, W.T())
+	for i := 0; i < n; i++ {
+		floats.Add(Z.RawRowView(i), b)
+	}
+	return Z
+}
+
+// PredictProba 计算每个样本属于每个类别的概率，结果是 n×k 矩阵，每行之和为 1
+func PredictProba(b []float64, W, X mat.Matrix) *mat.Dense {
+	P := Decision(b, W, X)
+	n, _ := P.Dims()
+	for i := 0; i < n; i++ {
+		Softmax(P.RawRowView(i))
+	}
+	return P
+}
+
+// PredictClass 预测每个样本的类别，即概率（等价地，线性部分）最大的类别
+func PredictClass(b []float64, W, X mat.Matrix) []int {
+	Z := Decision(b, W, X)
+	n, _ := Z.Dims()
+	pred := make([]int, n)
+	for i := range pred {
+		pred[i] = floats.MaxIdx(Z.RawRowView(i))
+	}
+	return pred
+}
+
+// CrossEntropyLoss 计算全部样本上的平均分类交叉熵 -log P(y=yTrue | x) = LSE(z) - z_y
+// yTrue: 类别标签 0..k-1，长度等于 X 的行数
+func CrossEntropyLoss(b []float64, W, X mat.Matrix, yTrue []int) float64 {
+	Z := Decision(b, W, X)
+	sumLoss := 0.0
+	for i, y := range yTrue {
+		z := Z.RawRowView(i)
+		sumLoss += LogSumExp(z) - z[y]
+	}
+	return sumLoss / float64(len(yTrue))
+}
+
+// Gradient 计算平均交叉熵对 b 和 W 的梯度
+// 残差矩阵 R = P - Y（Y 是标签的 one-hot 编码），则 dJ/db = 1/M * R 的列和，dJ/dW = 1/M * Rᵀ·X
+func Gradient(b []float64, W, X mat.Matrix, yTrue []int) ([]float64, *mat.Dense) {
+	n, d := X.Dims()
+	k := len(b)
+	M := float64(n)
+
+	R := PredictProba(b, W, X)
+	for i, y := range yTrue {
+		R.Set(i, y, R.At(i, y)-1)
+	}
+
+	bGradient := make([]float64, k)
+	for c := range bGradient {
+		bGradient[c] = floats.Sum(mat.Col(nil, c, R)) / M
+	}
+
+	wGradient := mat.NewDense(k, d, nil)
+	wGradient.Mul(R.T(), X)
+	wGradient.Scale(1/M, wGradient)
+
+	return bGradient, wGradient
+}
+
+// Data 把特征矩阵和类别标签包装成 train.Objective，参数按 Pack 的顺序排列；
+// 有样本权重时损失是加权交叉熵 Σ v_i·l_i / M
+type Data struct {
+	X       *mat.Dense // 特征矩阵，每行是一个样本
+	YTrue   []int      // 类别标签 0..K-1
+	K       int        // 类别数
+	Weights []float64  // 样本权重，为 nil 时每个样本的权重都是 1
+}
+
+// weight 返回第 i 个样本的权重
+func (d Data) weight(i int) float64 {
+	if d.Weights == nil {
+		return 1
+	}
+	return d.Weights[i]
+}
+
+func (d Data) NumSamples() int {
+	return len(d.YTrue)
+}
+
+// Loss 计算样本子集 idx 上的（加权）平均交叉熵损失
+func (d Data) Loss(params []float64, idx []int) float64 {
+	b, W := Unpack(params, d.K)
+	z := make([]float64, d.K)
+	sumLoss := 0.0
+	for _, i := range idx {
+		d.scores(z, b, W, i)
+		sumLoss += d.weight(i) * (LogSumExp(z) - z[d.YTrue[i]])
+	}
+	return sumLoss / float64(len(idx))
+}
+
+// LossGrad 计算样本子集 idx 上的（加权）平均交叉熵损失及其梯度，
+// 每个样本的梯度是 v·(p - onehot(y)) 与 [1, x] 的外积
+func (d Data) LossGrad(params []float64, idx []int, grad []float64) float64 {
+	b, W := Unpack(params, d.K)
+	gb, gW := Unpack(grad, d.K)
+	M := float64(len(idx))
+	for j := range grad {
+		grad[j] = 0
+	}
+
+	z := make([]float64, d.K)
+	sumLoss := 0.0
+	for _, i := range idx {
+		d.scores(z, b, W, i)
+		y, v := d.YTrue[i], d.weight(i)
+		sumLoss += v * (LogSumExp(z) - z[y])
+		Softmax(z)
+		z[y]--
+		row := d.X.RawRowView(i)
+		for c, r := range z {
+			gb[c] += v * r / M
+			floats.AddScaled(gW.RawRowView(c), v*r/M, row)
+		}
+	}
+	return sumLoss / M
+}
+
+// scores 把第 i 个样本对每个类别的线性部分写入 z
+func (d Data) scores(z, b []float64, W *mat.Dense, i int) {
+	row := d.X.RawRowView(i)
+	for c := range z {
+		z[c] = b[c] + floats.Dot(W.RawRowView(c), row)
+	}
+}
+
+// GradientDescent 训练 softmax 回归模型
+// X: 特征矩阵，每行是一个样本
+// yTrue: 类别标签 0..k-1
+// weights: 样本权重，为 nil 时每个样本的权重都是 1
+// k: 类别数
+// startingB, startingW: 参数初始值，为 nil 时全部初始化为 0
+// cfg: 学习率、轮数、优化器和停止判据等训练配置；正则化不惩罚 k 个偏置（除非 PenalizeIntercept）
+// 返回训练后的 b、W 以及每一轮的训练记录
+func GradientDescent(X *mat.Dense, yTrue []int, weights []float64, k int, startingB []float64, startingW mat.Matrix, cfg train.Config) ([]float64, *mat.Dense, train.History) {
+	_, d := X.Dims()
+	params := make([]float64, k+k*d)
+	if startingB != nil {
+		copy(params, startingB)
+	}
+	if startingW != nil {
+		mat.NewDense(k, d, params[k:]).Copy(startingW)
+	}
+
+	cfg.Penalty.Intercepts = k
+	hist := train.Run(Data{X: X, YTrue: yTrue, K: k, Weights: weights}, params, cfg)
+
+	b, W := Unpack(params, k)
+	return b, W, hist
+}
+
+// Boundary 类别 I 与 J 之间的决策边界 B + W·x = 0，其中 B = b_I - b_J、W = w_I - w_J；
+// 一侧 I 的概率大于 J，另一侧相反
+type Boundary struct {
+	I, J int
+	B    float64
+	W    []float64
+}
+
+// Boundaries 返回每一对类别之间的决策边界，共 k(k-1)/2 条
+func Boundaries(b []float64, W mat.Matrix) []Boundary {
+	k, d := W.Dims()
+	var out []Boundary
+	for i := 0; i < k; i++ {
+		for j := i + 1; j < k; j++ {
+			w := make([]float64, d)
+			for c := range w {
+				w[c] = W.At(i, c) - W.At(j, c)
+			}
+			out = append(out, Boundary{I: i, J: j, B: b[i] - b[j], W: w})
+		}
+	}
+	return out
+}
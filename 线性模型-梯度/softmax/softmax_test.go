@@ -0,0 +1,96 @@
+package softmax
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"ai/gradcheck"
+	"ai/train"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// randomData 生成 n×d 的特征矩阵，标签是 k 个随机线性函数中取值最大的那一个
+func randomData(rng *rand.Rand, n, d, k int) (*mat.Dense, []int) {
+	X := mat.NewDense(n, d, nil)
+	yTrue := make([]int, n)
+	W := mat.NewDense(k, d, gradcheck.RandomParams(rng, k*d, 1))
+	for i := range yTrue {
+		row := X.RawRowView(i)
+		for j := range row {
+			row[j] = rng.Float64()*10 - 5
+		}
+		best := math.Inf(-1)
+		for c := 0; c < k; c++ {
+			if s := mat.Dot(W.RowView(c), mat.NewVecDense(d, row)) + rng.NormFloat64()*0.5; s > best {
+				best, yTrue[i] = s, c
+			}
+		}
+	}
+	return X, yTrue
+}
+
+func TestGradients(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, dk := range [][2]int{{1, 2}, {2, 3}, {6, 5}} {
+		d, k := dk[0], dk[1]
+		X, yTrue := randomData(rng, 300, d, k)
+		for trial := 0; trial < 5; trial++ {
+			res := gradcheck.Check(
+				func(p []float64) float64 {
+					b, W := Unpack(p, k)
+					return CrossEntropyLoss(b, W, X, yTrue)
+				},
+				func(p []float64) []float64 {
+					b, W := Unpack(p, k)
+					return Pack(Gradient(b, W, X, yTrue))
+				},
+				gradcheck.RandomParams(rng, k+k*d, 1), gradcheck.DefaultEps,
+			)
+			if !res.OK(gradcheck.DefaultTol) {
+				t.Errorf("d=%d, k=%d: Gradient 与 CrossEntropyLoss 不一致\n%v", d, k, res)
+			}
+		}
+	}
+}
+
+func TestObjective(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	X, yTrue := randomData(rng, 1000, 3, 4)
+	weights := make([]float64, len(yTrue))
+	for i := range weights {
+		weights[i] = 0.1 + rng.Float64()*2.9
+	}
+	data := Data{X: X, YTrue: yTrue, K: 4}
+	weighted := Data{X: X, YTrue: yTrue, K: 4, Weights: weights}
+	for _, obj := range []train.Objective{data, train.NewParallel(data, 4), weighted, train.NewParallel(weighted, 4)} {
+		for trial := 0; trial < 5; trial++ {
+			res, err := gradcheck.CheckObjective(obj, gradcheck.RandomParams(rng, 16, 1), gradcheck.DefaultEps)
+			if err != nil {
+				t.Error(err)
+			}
+			if !res.OK(gradcheck.DefaultTol) {
+				t.Errorf("%T: LossGrad 与 Loss 不一致\n%v", obj, res)
+			}
+		}
+	}
+}
+
+// TestStability 检查线性部分很大时概率和交叉熵仍然有限
+func TestStability(t *testing.T) {
+	z := []float64{1000, -1000, 999}
+	if lse := LogSumExp(z); math.IsInf(lse, 0) || math.Abs(lse-(1000+math.Log1p(math.Exp(-1)))) > 1e-9 {
+		t.Errorf("LogSumExp(%v) = %g", z, lse)
+	}
+	Softmax(z)
+	if math.Abs(z[0]+z[1]+z[2]-1) > 1e-12 || z[1] != 0 {
+		t.Errorf("Softmax 结果 %v 不是概率分布", z)
+	}
+
+	X := mat.NewDense(1, 1, []float64{1000})
+	W := mat.NewDense(2, 1, []float64{1, -1})
+	if l := CrossEntropyLoss([]float64{0, 0}, W, X, []int{1}); math.IsInf(l, 0) || math.Abs(l-2000) > 1e-9 {
+		t.Errorf("判错时损失为 %g，应为 2000", l)
+	}
+}
@@ -0,0 +1,101 @@
+package train
+
+import (
+	"sync"
+)
+
+// MinShardSize 每个分片至少包含的样本数，样本太少时并行的调度开销会超过收益
+const MinShardSize = 256
+
+// Parallel 把 Objective 的损失和梯度计算分片到多个 goroutine 上并行执行。
+//
+// 样本子集按顺序切成至多 Workers 个连续的分片，每个分片由一个 goroutine
+// 调用被包装的 Objective 算出平均损失和梯度，最后按分片顺序以样本数加权合并。
+// 分片方式只取决于样本个数和 Workers，合并顺序固定，
+// 因此同样的输入总能得到逐位相同的结果，与 goroutine 的调度顺序无关。
+type Parallel struct {
+	Objective
+	Workers int // 并行的 goroutine 数，<=1 时直接调用被包装的 Objective
+}
+
+// NewParallel 用 workers 个 goroutine 并行计算 obj
+func NewParallel(obj Objective, workers int) *Parallel {
+	return &Parallel{Objective: obj, Workers: workers}
+}
+
+// shards 把 idx 切成连续的分片
+func (p *Parallel) shards(idx []int) [][]int {
+	count := p.Workers
+	if maxCount := len(idx) / MinShardSize; count > maxCount {
+		count = maxCount
+	}
+	if count <= 1 {
+		return [][]int{idx}
+	}
+
+	out := make([][]int, count)
+	for k := range out {
+		out[k] = idx[k*len(idx)/count : (k+1)*len(idx)/count]
+	}
+	return out
+}
+
+// Loss 并行计算样本子集 idx 上的平均损失
+func (p *Parallel) Loss(params []float64, idx []int) float64 {
+	shards := p.shards(idx)
+	if len(shards) == 1 {
+		return p.Objective.Loss(params, idx)
+	}
+
+	losses := make([]float64, len(shards))
+	var wg sync.WaitGroup
+	for k, shard := range shards {
+		wg.Add(1)
+		go func(k int, shard []int) {
+			defer wg.Done()
+			losses[k] = p.Objective.Loss(params, shard)
+		}(k, shard)
+	}
+	wg.Wait()
+
+	total := 0.0
+	for k, shard := range shards {
+		total += losses[k] * float64(len(shard)) / float64(len(idx))
+	}
+	return total
+}
+
+// LossGrad 并行计算样本子集 idx 上的平均损失和梯度，每个样本只计算一次
+func (p *Parallel) LossGrad(params []float64, idx []int, grad []float64) float64 {
+	shards := p.shards(idx)
+	if len(shards) == 1 {
+		return p.Objective.LossGrad(params, idx, grad)
+	}
+
+	losses := make([]float64, len(shards))
+	grads := make([][]float64, len(shards))
+	var wg sync.WaitGroup
+	for k, shard := range shards {
+		grads[k] = make([]float64, len(grad))
+		wg.Add(1)
+		go func(k int, shard []int) {
+			defer wg.Done()
+			losses[k] = p.Objective.LossGrad(params, shard, grads[k])
+		}(k, shard)
+	}
+	wg.Wait()
+
+	// 按分片顺序合并，保证结果与调度顺序无关
+	for j := range grad {
+		grad[j] = 0
+	}
+	total := 0.0
+	for k, shard := range shards {
+		weight := float64(len(shard)) / float64(len(idx))
+		total += losses[k] * weight
+		for j, g := range grads[k] {
+			grad[j] += g * weight
+		}
+	}
+	return total
+}
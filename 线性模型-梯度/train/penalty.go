@@ -0,0 +1,115 @@
+package train
+
+import (
+	"fmt"
+	"math"
+)
+
+// Penalty ElasticNet 形式的参数正则化：
+//
+//	Alpha * (L1Ratio*|w|₁ + (1-L1Ratio)/2*|w|₂²)
+//
+// L1Ratio 为 0 时是 Ridge（L2），为 1 时是 Lasso（L1）。
+// 按照参数顺序约定，params[0] 是偏置（截距），默认不参与惩罚；
+// softmax 回归每个类别有一个偏置，排在参数最前面，由 Intercepts 指定个数。
+type Penalty struct {
+	Alpha             float64 // 正则化强度，0 表示不正则化
+	L1Ratio           float64 // L1 部分所占比例，取值 [0, 1]
+	PenalizeIntercept bool    // 是否也惩罚偏置
+	Intercepts        int     // 参数开头的偏置个数，0 表示只有 params[0] 一个
+}
+
+// Ridge 创建 L2 正则化
+func Ridge(alpha float64) Penalty {
+	return Penalty{Alpha: alpha}
+}
+
+// Lasso 创建 L1 正则化
+func Lasso(alpha float64) Penalty {
+	return Penalty{Alpha: alpha, L1Ratio: 1}
+}
+
+// ElasticNet 创建 L1 和 L2 混合的正则化
+func ElasticNet(alpha, l1Ratio float64) Penalty {
+	return Penalty{Alpha: alpha, L1Ratio: l1Ratio}
+}
+
+// NewPenalty 按名称创建正则化，name 为 ridge、lasso、elasticnet 之一，为空时不正则化；
+// l1Ratio 只在 elasticnet 时使用
+func NewPenalty(name string, alpha, l1Ratio float64) (Penalty, error) {
+	switch name {
+	case "":
+		return Penalty{}, nil
+	case "ridge":
+		return Ridge(alpha), nil
+	case "lasso":
+		return Lasso(alpha), nil
+	case "elasticnet":
+		if l1Ratio < 0 || l1Ratio > 1 {
+			return Penalty{}, fmt.Errorf("train: l1Ratio 必须在 [0, 1] 内，实际为 %g", l1Ratio)
+		}
+		return ElasticNet(alpha, l1Ratio), nil
+	}
+	return Penalty{}, fmt.Errorf("train: 未知的正则化 %q，可选：ridge, lasso, elasticnet", name)
+}
+
+// first 返回第一个参与惩罚的参数下标
+func (p Penalty) first() int {
+	if p.PenalizeIntercept {
+		return 0
+	}
+	return max(p.Intercepts, 1)
+}
+
+// L1 返回 L1 部分的系数 Alpha*L1Ratio
+func (p Penalty) L1() float64 { return p.Alpha * p.L1Ratio }
+
+// L2 返回 L2 部分的系数 Alpha*(1-L1Ratio)
+func (p Penalty) L2() float64 { return p.Alpha * (1 - p.L1Ratio) }
+
+// Value 计算正则化项的值
+func (p Penalty) Value(params []float64) float64 {
+	if p.Alpha == 0 {
+		return 0
+	}
+	sumAbs, sumSq := 0.0, 0.0
+	for _, w := range params[p.first():] {
+		sumAbs += math.Abs(w)
+		sumSq += w * w
+	}
+	return p.L1()*sumAbs + p.L2()/2*sumSq
+}
+
+// AddGrad 把正则化中光滑部分（L2）的梯度累加到 grad；
+// L1 部分不可导，由 Prox 在每次更新后处理
+func (p Penalty) AddGrad(params, grad []float64) {
+	if p.L2() == 0 {
+		return
+	}
+	for j := p.first(); j < len(params); j++ {
+		grad[j] += p.L2() * params[j]
+	}
+}
+
+// Prox 对 params 做 L1 部分的近端映射（软阈值），步长为 lr：
+// w = sign(w) * max(|w| - lr*Alpha*L1Ratio, 0)
+func (p Penalty) Prox(params []float64, lr float64) {
+	threshold := lr * p.L1()
+	if threshold == 0 {
+		return
+	}
+	for j := p.first(); j < len(params); j++ {
+		params[j] = SoftThreshold(params[j], threshold)
+	}
+}
+
+// SoftThreshold 软阈值函数 sign(x) * max(|x| - t, 0)
+func SoftThreshold(x, t float64) float64 {
+	switch {
+	case x > t:
+		return x - t
+	case x < -t:
+		return x + t
+	}
+	return 0
+}
@@ -0,0 +1,30 @@
+package train_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"ai/gradcheck"
+	"ai/train"
+)
+
+// TestPenaltyGrad 检查 Ridge 的 AddGrad 与 Value 一致；L1 部分由 Prox 处理，不参与检查
+func TestPenaltyGrad(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, penalty := range []train.Penalty{train.Ridge(0.3), {Alpha: 0.3, PenalizeIntercept: true}} {
+		for trial := 0; trial < 5; trial++ {
+			res := gradcheck.Check(
+				penalty.Value,
+				func(p []float64) []float64 {
+					grad := make([]float64, len(p))
+					penalty.AddGrad(p, grad)
+					return grad
+				},
+				gradcheck.RandomParams(rng, 4, 3), gradcheck.DefaultEps,
+			)
+			if !res.OK(gradcheck.DefaultTol) {
+				t.Errorf("%+v: AddGrad 与 Value 不一致\n%v", penalty, res)
+			}
+		}
+	}
+}
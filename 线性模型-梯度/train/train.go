@@ -0,0 +1,202 @@
+// Package train 实现通用的梯度下降训练循环。
+//
+// 模型只需要把自己描述成 Objective（给出样本子集上的损失和梯度），
+// 就可以复用全批量、小批量和随机梯度下降，以及 optim 中的各种优化器。
+// 参数统一按 [偏置, 权重...] 的顺序排成一个 []float64。
+package train
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"ai/optim"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+// Objective 可微的目标函数
+type Objective interface {
+	// NumSamples 返回样本总数
+	NumSamples() int
+	// Loss 计算样本子集 idx 上的平均损失
+	Loss(params []float64, idx []int) float64
+	// LossGrad 计算样本子集 idx 上的平均损失，并把平均梯度写入 grad（长度与 params 一致）
+	LossGrad(params []float64, idx []int, grad []float64) float64
+}
+
+// Config 训练配置
+type Config struct {
+	LR        float64         // 学习率，设置了 Schedule 时不使用
+	Schedule  optim.Schedule  // 学习率调度，按更新步数给出学习率，为 nil 时固定使用 LR
+	Epochs    int             // 遍历全部样本的轮数，全批量时等于迭代次数
+	BatchSize int             // 每步使用的样本数，<=0 或不小于样本总数时为全批量，1 为纯随机梯度下降
+	Seed      int64           // 每轮打乱样本顺序所用的随机种子
+	Optimizer optim.Optimizer // 参数更新规则，为 nil 时使用普通梯度下降
+	LogEvery  int             // 每多少轮打印一次损失，<=0 时不打印
+	Stop      StopRule        // 提前停止的判据，零值表示总是跑满 Epochs 轮
+	Penalty   Penalty         // 参数正则化，零值表示不正则化
+	Workers   int             // 并行计算损失和梯度的 goroutine 数，<=1 时单线程计算
+}
+
+// StopRule 收敛判据，每轮结束时检查一次，任意一个启用的判据满足即停止训练；
+// 字段为零值时不启用对应判据
+type StopRule struct {
+	LossTol  float64 // 相邻两轮损失变化的绝对值小于 LossTol
+	GradTol  float64 // 全部样本上梯度的 L2 范数小于 GradTol
+	ParamTol float64 // 一轮内参数变化量的 L2 范数小于 ParamTol
+	Patience int     // 连续 Patience 轮损失没有比最好值再下降超过 MinDelta
+	MinDelta float64
+}
+
+// History 训练过程记录，每个切片的第 i 个元素对应第 i 轮结束时的状态
+type History struct {
+	Loss       []float64   // 全部样本上的损失，包含正则化项
+	GradNorm   []float64   // 全部样本上梯度的 L2 范数；有 L1 正则化时是近端梯度映射的范数
+	Params     [][]float64 // 参数的副本
+	LR         []float64   // 该轮最后一步使用的学习率
+	StopReason string      // 提前停止的原因，跑满全部轮数时为空
+}
+
+// Epochs 返回实际执行的轮数
+func (h History) Epochs() int {
+	return len(h.Loss)
+}
+
+// check 判断第 epoch 轮结束后是否满足 rule，返回停止原因；
+// wait 记录损失连续没有改善的轮数，best 记录目前最好的损失
+func (rule StopRule) check(h History, wait *int, best *float64) string {
+	last := len(h.Loss) - 1
+	loss := h.Loss[last]
+
+	if rule.GradTol > 0 && h.GradNorm[last] < rule.GradTol {
+		return fmt.Sprintf("梯度范数 %.3g 小于 %g", h.GradNorm[last], rule.GradTol)
+	}
+	if last > 0 {
+		if change := math.Abs(loss - h.Loss[last-1]); rule.LossTol > 0 && change < rule.LossTol {
+			return fmt.Sprintf("损失变化 %.3g 小于 %g", change, rule.LossTol)
+		}
+		if change := floats.Distance(h.Params[last], h.Params[last-1], 2); rule.ParamTol > 0 && change < rule.ParamTol {
+			return fmt.Sprintf("参数变化 %.3g 小于 %g", change, rule.ParamTol)
+		}
+	}
+	if rule.Patience > 0 {
+		if loss < *best-rule.MinDelta {
+			*best = loss
+			*wait = 0
+		} else {
+			*wait++
+			if *wait >= rule.Patience {
+				return fmt.Sprintf("连续 %d 轮损失没有改善", *wait)
+			}
+		}
+	}
+	return ""
+}
+
+// All 返回 0..n-1 的下标，表示使用全部样本
+func All(n int) []int {
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	return idx
+}
+
+// TotalSteps 返回在 n 个样本上按 cfg 训练时的总更新步数，用于推算学习率调度的衰减速度
+func (cfg Config) TotalSteps(n int) int {
+	batchSize := cfg.batchSize(n)
+	return cfg.Epochs * ((n + batchSize - 1) / batchSize)
+}
+
+// batchSize 返回实际使用的批大小
+func (cfg Config) batchSize(n int) int {
+	if cfg.BatchSize <= 0 || cfg.BatchSize > n {
+		return n
+	}
+	return cfg.BatchSize
+}
+
+// Run 按 cfg 训练 obj，原地更新 params，返回每一轮的训练记录；
+// 满足 cfg.Stop 中的判据时提前结束
+func Run(obj Objective, params []float64, cfg Config) History {
+	if cfg.Workers > 1 {
+		obj = NewParallel(obj, cfg.Workers)
+	}
+	n := obj.NumSamples()
+	opt := cfg.Optimizer
+	if opt == nil {
+		opt = optim.NewSGD()
+	}
+	batchSize := cfg.batchSize(n)
+
+	schedule := cfg.Schedule
+	if schedule == nil {
+		schedule = optim.Constant{LR: cfg.LR}
+	}
+
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	order := All(n)
+	grad := make([]float64, len(params))
+	fullGrad := make([]float64, len(params))
+	var hist History
+	step := 0
+	lr := schedule.Rate(0)
+	wait, best := 0, math.Inf(1)
+
+	for epoch := 0; epoch < cfg.Epochs; epoch++ {
+		// 小批量模式下每轮重新打乱样本顺序，全批量时顺序无关紧要
+		if batchSize < n {
+			rng.Shuffle(n, func(i, j int) { order[i], order[j] = order[j], order[i] })
+		}
+
+		for start := 0; start < n; start += batchSize {
+			end := start + batchSize
+			if end > n {
+				end = n
+			}
+			lr = schedule.Rate(step)
+			obj.LossGrad(params, order[start:end], grad)
+			cfg.Penalty.AddGrad(params, grad)
+			opt.Step(params, grad, lr)
+			// L1 部分用近端梯度法处理：先按光滑部分的梯度更新，再做软阈值
+			cfg.Penalty.Prox(params, lr)
+			step++
+		}
+
+		loss := obj.LossGrad(params, order, fullGrad) + cfg.Penalty.Value(params)
+		cfg.Penalty.AddGrad(params, fullGrad)
+		hist.Loss = append(hist.Loss, loss)
+		hist.GradNorm = append(hist.GradNorm, gradNorm(params, fullGrad, lr, cfg.Penalty))
+		hist.Params = append(hist.Params, append([]float64(nil), params...))
+		hist.LR = append(hist.LR, lr)
+
+		if cfg.LogEvery > 0 && epoch%cfg.LogEvery == 0 {
+			fmt.Printf("Epoch:%d, loss:%f, lr:%.6g, params:%.6f\n", epoch, loss, lr, params)
+		}
+
+		if reason := cfg.Stop.check(hist, &wait, &best); reason != "" {
+			hist.StopReason = reason
+			if cfg.LogEvery > 0 {
+				fmt.Printf("Stopped at epoch %d: %s\n", epoch, reason)
+			}
+			break
+		}
+	}
+
+	return hist
+}
+
+// gradNorm 返回衡量是否到达最优点的梯度范数。
+// 有 L1 正则化时目标函数不可导，改用近端梯度映射 (params - prox(params - lr*grad)) / lr 的范数，
+// 它在最优点同样等于 0
+func gradNorm(params, grad []float64, lr float64, penalty Penalty) float64 {
+	if penalty.L1() == 0 || lr == 0 {
+		return floats.Norm(grad, 2)
+	}
+	next := make([]float64, len(params))
+	floats.AddScaledTo(next, params, -lr, grad)
+	penalty.Prox(next, lr)
+	floats.Sub(next, params)
+	return floats.Norm(next, 2) / lr
+}
@@ -0,0 +1,47 @@
+package train
+
+import "fmt"
+
+// ClassWeightNames 返回支持的类别权重方式
+func ClassWeightNames() []string {
+	return []string{"balanced"}
+}
+
+// ClassWeights 按名称计算 k 个类别的权重：为空时返回 nil（不加权），
+// balanced 按 scikit-learn 的 class_weight="balanced" 取 n / (k * n_c)，
+// 样本少的类别权重大，加权后每个类别对损失的总贡献相同；没有样本的类别权重为 0
+func ClassWeights(name string, labels []int, k int) ([]float64, error) {
+	switch name {
+	case "":
+		return nil, nil
+	case "balanced":
+		counts := make([]int, k)
+		for _, y := range labels {
+			counts[y]++
+		}
+		weights := make([]float64, k)
+		for c, n := range counts {
+			if n > 0 {
+				weights[c] = float64(len(labels)) / float64(k*n)
+			}
+		}
+		return weights, nil
+	}
+	return nil, fmt.Errorf("train: 未知的类别权重 %q，可选：%v", name, ClassWeightNames())
+}
+
+// SampleWeights 把类别权重展开成每个样本的权重，再乘以 base（为 nil 时视为全 1）；
+// classWeights 为 nil 时直接返回 base
+func SampleWeights(labels []int, classWeights, base []float64) []float64 {
+	if classWeights == nil {
+		return base
+	}
+	weights := make([]float64, len(labels))
+	for i, y := range labels {
+		weights[i] = classWeights[y]
+		if base != nil {
+			weights[i] *= base[i]
+		}
+	}
+	return weights
+}